@@ -0,0 +1,328 @@
+package cortex
+
+import (
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	BackstageAPIVersion = "backstage.io/v1alpha1"
+
+	BackstageAnnotationGithubSlug = "github.com/project-slug"
+	BackstageAnnotationGitlabSlug = "gitlab.com/project-slug"
+)
+
+// BackstageEntity is a Backstage catalog entity, as in a catalog-info.yaml
+type BackstageEntity struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   BackstageMetadata `yaml:"metadata"`
+	Spec       BackstageSpec     `yaml:"spec,omitempty"`
+}
+
+type BackstageMetadata struct {
+	Name        string            `yaml:"name"`
+	Namespace   string            `yaml:"namespace,omitempty"`
+	Title       string            `yaml:"title,omitempty"`
+	Description string            `yaml:"description,omitempty"`
+	Tags        []string          `yaml:"tags,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+	Links       []BackstageLink   `yaml:"links,omitempty"`
+}
+
+type BackstageLink struct {
+	URL   string `yaml:"url"`
+	Title string `yaml:"title,omitempty"`
+	Type  string `yaml:"type,omitempty"`
+}
+
+// BackstageSpec has the spec fields of the kinds which map to Cortex entities
+type BackstageSpec struct {
+	Type        string   `yaml:"type,omitempty"`
+	Lifecycle   string   `yaml:"lifecycle,omitempty"`
+	Owner       string   `yaml:"owner,omitempty"`
+	System      string   `yaml:"system,omitempty"`
+	Domain      string   `yaml:"domain,omitempty"`
+	SubdomainOf string   `yaml:"subdomainOf,omitempty"`
+	Parent      string   `yaml:"parent,omitempty"`
+	DependsOn   []string `yaml:"dependsOn,omitempty"`
+	// Required for groups, even when empty, so it is only nil for other kinds
+	Children *[]string `yaml:"children,omitempty"`
+}
+
+// The descriptor keys which BackstageEntityFromDescriptor always maps, other
+// keys are unmapped unless they are handled below.
+var backstageMappedKeys = map[string]bool{
+	"x-cortex-tag":    true,
+	"title":           true,
+	"description":     true,
+	"x-cortex-type":   true,
+	"x-cortex-groups": true,
+	"x-cortex-link":   true,
+}
+
+// BackstageEntityFromDescriptor converts a descriptor into the equivalent
+// Backstage entity. It also returns the sorted descriptor keys which could not
+// be mapped, or were only partly mapped, and any required Backstage fields,
+// like spec.owner, which could not be filled.
+//
+// Services become components, domains become systems, teams become groups and
+// any other type becomes a resource of that type. Backstage has a single owner
+// and parent, so only the first of each is used. Slack owners are skipped.
+func BackstageEntityFromDescriptor(info CortexInfo) (BackstageEntity, []string) {
+	mapped := map[string]bool{}
+	for key := range backstageMappedKeys {
+		mapped[key] = true
+	}
+
+	entity := BackstageEntity{
+		APIVersion: BackstageAPIVersion,
+		Metadata: BackstageMetadata{
+			Name:        info.Tag,
+			Title:       info.Title,
+			Description: info.Description,
+			Tags:        info.Groups,
+		},
+	}
+	for _, link := range info.Link {
+		entity.Metadata.Links = append(entity.Metadata.Links, BackstageLink{URL: link.Url, Title: link.Name, Type: link.Type})
+	}
+
+	var parent string
+	if len(info.Parents) > 0 {
+		parent = info.Parents[0].Tag
+		mapped["x-cortex-parents"] = len(info.Parents) == 1
+	}
+	var owner string
+	for _, cortexOwner := range info.Owners {
+		ref, complete := backstageOwnerRef(cortexOwner)
+		if ref != "" {
+			owner = ref
+			mapped["x-cortex-owners"] = len(info.Owners) == 1 && complete
+			break
+		}
+	}
+
+	switch info.Type {
+	case "", "service":
+		entity.Kind = "Component"
+		entity.Spec = BackstageSpec{Type: "service", Lifecycle: "unknown", Owner: owner, System: parent}
+	case "domain":
+		entity.Kind = "System"
+		entity.Spec = BackstageSpec{Owner: owner, Domain: parent}
+	case "team":
+		// Groups have no owner
+		entity.Kind = "Group"
+		entity.Spec = BackstageSpec{Type: "team", Parent: parent, Children: &[]string{}}
+		mapped["x-cortex-owners"] = false
+	default:
+		entity.Kind = "Resource"
+		entity.Spec = BackstageSpec{Type: info.Type, Owner: owner, System: parent}
+	}
+
+	git := info.Git.Normalize()
+	if git.BasePath == "" && git.Alias == "" {
+		switch git.Provider {
+		case "github":
+			entity.Metadata.Annotations = map[string]string{BackstageAnnotationGithubSlug: git.Repository}
+			mapped["x-cortex-git"] = true
+		case "gitlab":
+			entity.Metadata.Annotations = map[string]string{BackstageAnnotationGitlabSlug: git.Repository}
+			mapped["x-cortex-git"] = true
+		}
+	}
+
+	var unmapped []string
+	if entity.Kind != "Group" && entity.Spec.Owner == "" {
+		unmapped = append(unmapped, "spec.owner")
+	}
+	for key := range descriptorKeys(info) {
+		if !mapped[key] {
+			unmapped = append(unmapped, key)
+		}
+	}
+	sort.Strings(unmapped)
+	return entity, unmapped
+}
+
+// backstageOwnerRef returns the Backstage entity reference of an owner, and
+// whether the reference keeps everything about the owner. Users are named
+// after the local part of their email, as an email is not a valid name, so
+// they always lose the domain. Slack owners have no reference, and groups from
+// an identity provider lose their provider.
+func backstageOwnerRef(owner CortexOwner) (string, bool) {
+	plain := owner.Inheritance == "" && owner.Description == ""
+	switch {
+	case owner.Type == "email" && owner.Email != "":
+		if name := backstageName(strings.Split(owner.Email, "@")[0]); name != "" {
+			return "user:" + name, false
+		}
+	case owner.Type == "group" && owner.Name != "":
+		if name := backstageName(owner.Name); name != "" {
+			cortexGroup := owner.Provider == "" || strings.EqualFold(owner.Provider, "CORTEX")
+			return "group:" + name, plain && cortexGroup && name == owner.Name
+		}
+	}
+	return "", false
+}
+
+// backstageName makes a valid Backstage entity name: ASCII letters and digits,
+// separated by single dashes, underscores or dots, at most 63 characters.
+// Other characters become dashes. It is empty if there are no letters or
+// digits.
+func backstageName(value string) string {
+	var name []byte
+	var separator byte
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+			if separator != 0 && len(name) > 0 {
+				name = append(name, separator)
+			}
+			separator = 0
+			name = append(name, c)
+		case separator == 0 && (c == '-' || c == '_' || c == '.'):
+			separator = c
+		case separator == 0:
+			separator = '-'
+		}
+	}
+	if len(name) > 63 {
+		return strings.TrimRight(string(name[:63]), "-_.")
+	}
+	return string(name)
+}
+
+// descriptorKeys returns the keys which are set in the info, including
+// extensions.
+func descriptorKeys(info CortexInfo) map[string]bool {
+	keys := make(map[string]bool)
+	out, err := yaml.Marshal(info)
+	if err != nil {
+		return keys
+	}
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal(out, &fields); err != nil {
+		return keys
+	}
+	for key := range fields {
+		keys[key] = true
+	}
+	return keys
+}
+
+// The Cortex type of each Backstage kind. Resources keep their spec.type, and
+// other kinds, like User and Location, have no Cortex equivalent.
+var backstageKindTypes = map[string]string{
+	"Component": "service",
+	"API":       "api",
+	"System":    "domain",
+	"Domain":    "domain",
+	"Group":     "team",
+}
+
+// isBackstageDocument is true if the document is a Backstage entity.
+func isBackstageDocument(document *yaml.Node) bool {
+	var header struct {
+		APIVersion string `yaml:"apiVersion"`
+	}
+	if err := document.Decode(&header); err != nil {
+		return false
+	}
+	return strings.HasPrefix(header.APIVersion, "backstage.io/")
+}
+
+// parseBackstageDocument decodes a Backstage entity into a descriptor. It is
+// not ok if the kind has no Cortex equivalent.
+func parseBackstageDocument(document *yaml.Node) (CortexInfo, bool, error) {
+	var entity BackstageEntity
+	var warnings []string
+	if err := document.Decode(&entity); err != nil {
+		typeErr, ok := err.(*yaml.TypeError)
+		if !ok {
+			return CortexInfo{}, false, err
+		}
+		warnings = typeErr.Errors
+	}
+	var raw map[string]interface{}
+	if err := document.Decode(&raw); err != nil {
+		return CortexInfo{}, false, err
+	}
+	info, ok := DescriptorFromBackstage(entity)
+	if !ok {
+		return CortexInfo{}, false, nil
+	}
+	info.Raw = raw
+	info.ParseWarnings = append(warnings, info.ParseWarnings...)
+	return info, true, nil
+}
+
+// DescriptorFromBackstage converts a Backstage entity into the equivalent
+// descriptor, the reverse of BackstageEntityFromDescriptor. It is not ok if the
+// kind has no Cortex equivalent. User owners are only kept if their name is an
+// email, otherwise there is a parse warning.
+func DescriptorFromBackstage(entity BackstageEntity) (CortexInfo, bool) {
+	cortexType, ok := backstageKindTypes[entity.Kind]
+	if entity.Kind == "Resource" {
+		cortexType, ok = firstNonEmpty(entity.Spec.Type, "resource"), true
+	}
+	if !ok {
+		return CortexInfo{}, false
+	}
+
+	info := CortexInfo{
+		Tag:          entity.Metadata.Name,
+		Title:        entity.Metadata.Title,
+		Description:  entity.Metadata.Description,
+		Type:         cortexType,
+		Groups:       entity.Metadata.Tags,
+		SourceFormat: DescriptorFormatBackstage,
+	}
+	for _, link := range entity.Metadata.Links {
+		info.Link = append(info.Link, CortexLink{Name: link.Title, Type: link.Type, Url: link.URL})
+	}
+
+	if entity.Spec.Owner != "" {
+		kind, name := parseBackstageRef(entity.Spec.Owner, "group")
+		switch {
+		case kind == "group":
+			info.Owners = []CortexOwner{{Type: "group", Name: name}}
+		case kind == "user" && strings.Contains(name, "@"):
+			info.Owners = []CortexOwner{{Type: "email", Email: name}}
+		default:
+			info.ParseWarnings = append(info.ParseWarnings, "spec.owner: "+entity.Spec.Owner+" is not a group or an email")
+		}
+	}
+
+	if parent := firstNonEmpty(entity.Spec.System, entity.Spec.Domain, entity.Spec.SubdomainOf, entity.Spec.Parent); parent != "" {
+		_, name := parseBackstageRef(parent, "")
+		info.Parents = []CortexTag{{Tag: name}}
+	}
+	for _, dependency := range entity.Spec.DependsOn {
+		_, name := parseBackstageRef(dependency, "")
+		info.Dependency.Cortex = append(info.Dependency.Cortex, CortexDependencyCortex{Tag: name})
+	}
+
+	if slug := entity.Metadata.Annotations[BackstageAnnotationGithubSlug]; slug != "" {
+		info.Git.Github.Repository = slug
+	} else if slug := entity.Metadata.Annotations[BackstageAnnotationGitlabSlug]; slug != "" {
+		info.Git.Gitlab.Repository = slug
+	}
+	return info, true
+}
+
+// parseBackstageRef splits an entity reference, [kind:][namespace/]name, into
+// its lower case kind and name.
+func parseBackstageRef(ref string, defaultKind string) (string, string) {
+	kind := defaultKind
+	if i := strings.Index(ref, ":"); i >= 0 {
+		kind, ref = strings.ToLower(ref[:i]), ref[i+1:]
+	}
+	if i := strings.LastIndex(ref, "/"); i >= 0 {
+		ref = ref[i+1:]
+	}
+	return kind, ref
+}
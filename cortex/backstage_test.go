@@ -0,0 +1,221 @@
+package cortex
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBackstageEntityFromDescriptor(t *testing.T) {
+	g := NewWithT(t)
+
+	descriptor := `
+title: Service 1
+description: The first service
+x-cortex-tag: service1
+x-cortex-type: service
+x-cortex-groups: [python, tier-1]
+x-cortex-owners:
+  - type: group
+    name: team-a
+x-cortex-parents:
+  - tag: payments
+x-cortex-link:
+  - name: Runbook
+    type: runbook
+    url: https://example.com/runbook
+x-cortex-git:
+  github:
+    repository: my-org/service1
+x-cortex-slack:
+  channels:
+    - name: service1
+x-cortex-future-feature: true
+`
+	var info CortexInfo
+	g.Expect(yaml.Unmarshal([]byte(descriptor), &info)).To(Succeed())
+
+	entity, unmapped := BackstageEntityFromDescriptor(info)
+	g.Expect(entity).To(Equal(BackstageEntity{
+		APIVersion: "backstage.io/v1alpha1",
+		Kind:       "Component",
+		Metadata: BackstageMetadata{
+			Name:        "service1",
+			Title:       "Service 1",
+			Description: "The first service",
+			Tags:        []string{"python", "tier-1"},
+			Annotations: map[string]string{"github.com/project-slug": "my-org/service1"},
+			Links:       []BackstageLink{{URL: "https://example.com/runbook", Title: "Runbook", Type: "runbook"}},
+		},
+		Spec: BackstageSpec{Type: "service", Lifecycle: "unknown", Owner: "group:team-a", System: "payments"},
+	}))
+	g.Expect(unmapped).To(Equal([]string{"x-cortex-future-feature", "x-cortex-slack"}))
+}
+
+func TestBackstageEntityFromDescriptorKinds(t *testing.T) {
+	g := NewWithT(t)
+
+	owners := []CortexOwner{{Type: "email", Email: "jane.doe@example.com"}, {Type: "group", Name: "team-a"}}
+	parents := []CortexTag{{Tag: "parent1"}, {Tag: "parent2"}}
+
+	// Only the first owner and parent are kept
+	entity, unmapped := BackstageEntityFromDescriptor(CortexInfo{Tag: "payments", Type: "domain", Owners: owners, Parents: parents})
+	g.Expect(entity.Kind).To(Equal("System"))
+	g.Expect(entity.Spec).To(Equal(BackstageSpec{Owner: "user:jane.doe", Domain: "parent1"}))
+	g.Expect(unmapped).To(Equal([]string{"x-cortex-owners", "x-cortex-parents"}))
+
+	// Groups have no owner, and always have children
+	entity, unmapped = BackstageEntityFromDescriptor(CortexInfo{Tag: "team-a", Type: "team", Owners: owners[:1], Parents: parents[:1]})
+	g.Expect(entity.Kind).To(Equal("Group"))
+	g.Expect(entity.Spec).To(Equal(BackstageSpec{Type: "team", Parent: "parent1", Children: &[]string{}}))
+	g.Expect(unmapped).To(Equal([]string{"x-cortex-owners"}))
+	out, err := yaml.Marshal(entity.Spec)
+	g.Expect(err).To(BeNil())
+	g.Expect(string(out)).To(Equal("type: team\nparent: parent1\nchildren: []\n"))
+
+	// Users lose the domain of their email, and invalid characters in names are replaced
+	entity, unmapped = BackstageEntityFromDescriptor(CortexInfo{Tag: "service4", Owners: owners[:1]})
+	g.Expect(entity.Spec.Owner).To(Equal("user:jane.doe"))
+	g.Expect(unmapped).To(Equal([]string{"x-cortex-owners"}))
+	entity, unmapped = BackstageEntityFromDescriptor(CortexInfo{Tag: "service4", Owners: []CortexOwner{{Type: "group", Name: "Team A (EU)"}}})
+	g.Expect(entity.Spec.Owner).To(Equal("group:Team-A-EU"))
+	g.Expect(unmapped).To(Equal([]string{"x-cortex-owners"}))
+
+	// Other types are resources, git in a monorepo has no slug annotation. Components,
+	// systems and resources need an owner, so a missing one is reported.
+	git := CortexGit{Github: CortexGitRepository{Repository: "my-org/monorepo", BasePath: "db"}}
+	entity, unmapped = BackstageEntityFromDescriptor(CortexInfo{Tag: "db1", Type: "database", Git: git})
+	g.Expect(entity.Kind).To(Equal("Resource"))
+	g.Expect(entity.Spec).To(Equal(BackstageSpec{Type: "database"}))
+	g.Expect(entity.Metadata.Annotations).To(BeNil())
+	g.Expect(unmapped).To(Equal([]string{"spec.owner", "x-cortex-git"}))
+
+	// Slack owners are skipped, and groups from an identity provider are only partly mapped
+	slack := CortexOwner{Type: "slack", Channel: "team-a"}
+	entity, unmapped = BackstageEntityFromDescriptor(CortexInfo{Tag: "service3", Owners: []CortexOwner{slack, owners[1]}})
+	g.Expect(entity.Spec.Owner).To(Equal("group:team-a"))
+	g.Expect(unmapped).To(Equal([]string{"x-cortex-owners"}))
+	entity, unmapped = BackstageEntityFromDescriptor(CortexInfo{Tag: "service3", Owners: []CortexOwner{slack}})
+	g.Expect(entity.Spec.Owner).To(BeEmpty())
+	g.Expect(unmapped).To(Equal([]string{"spec.owner", "x-cortex-owners"}))
+	entity, unmapped = BackstageEntityFromDescriptor(CortexInfo{Tag: "service3", Owners: []CortexOwner{{Type: "group", Name: "Engineering", Provider: "OKTA"}}})
+	g.Expect(entity.Spec.Owner).To(Equal("group:Engineering"))
+	g.Expect(unmapped).To(Equal([]string{"x-cortex-owners"}))
+
+	entity, unmapped = BackstageEntityFromDescriptor(CortexInfo{Tag: "service2", Owners: owners[1:], Git: CortexGit{Gitlab: CortexGitRepository{Repository: "my-org/service2"}}})
+	g.Expect(entity.Kind).To(Equal("Component"))
+	g.Expect(entity.Metadata.Annotations).To(Equal(map[string]string{"gitlab.com/project-slug": "my-org/service2"}))
+	g.Expect(unmapped).To(BeEmpty())
+}
+
+func TestDescriptorFromBackstage(t *testing.T) {
+	g := NewWithT(t)
+
+	entity := BackstageEntity{
+		APIVersion: "backstage.io/v1alpha1",
+		Kind:       "Component",
+		Metadata: BackstageMetadata{
+			Name:        "service1",
+			Title:       "Service 1",
+			Description: "The first service",
+			Tags:        []string{"python"},
+			Annotations: map[string]string{"github.com/project-slug": "my-org/service1"},
+			Links:       []BackstageLink{{URL: "https://example.com/runbook", Title: "Runbook", Type: "runbook"}},
+		},
+		Spec: BackstageSpec{
+			Type:      "website",
+			Lifecycle: "production",
+			Owner:     "team-a",
+			System:    "system:default/payments",
+			DependsOn: []string{"component:service2", "resource:default/db1"},
+		},
+	}
+
+	info, ok := DescriptorFromBackstage(entity)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(info).To(Equal(CortexInfo{
+		Tag:          "service1",
+		Title:        "Service 1",
+		Description:  "The first service",
+		Type:         "service",
+		Groups:       []string{"python"},
+		Owners:       []CortexOwner{{Type: "group", Name: "team-a"}},
+		Parents:      []CortexTag{{Tag: "payments"}},
+		Link:         []CortexLink{{Name: "Runbook", Type: "runbook", Url: "https://example.com/runbook"}},
+		Git:          CortexGit{Github: CortexGitRepository{Repository: "my-org/service1"}},
+		Dependency:   CortexDependency{Cortex: []CortexDependencyCortex{{Tag: "service2"}, {Tag: "db1"}}},
+		SourceFormat: "backstage",
+	}))
+
+	// A round trip keeps the mapped fields
+	back, _ := BackstageEntityFromDescriptor(info)
+	g.Expect(back.Metadata).To(Equal(entity.Metadata))
+	g.Expect(back.Spec.Owner).To(Equal("group:team-a"))
+	g.Expect(back.Spec.System).To(Equal("payments"))
+
+	// Resources keep their type and users need to be named by their email
+	info, ok = DescriptorFromBackstage(BackstageEntity{
+		Kind:     "Resource",
+		Metadata: BackstageMetadata{Name: "db1"},
+		Spec:     BackstageSpec{Type: "database", Owner: "user:jane.doe"},
+	})
+	g.Expect(ok).To(BeTrue())
+	g.Expect(info.Type).To(Equal("database"))
+	g.Expect(info.Owners).To(BeNil())
+	g.Expect(info.ParseWarnings).To(Equal([]string{"spec.owner: user:jane.doe is not a group or an email"}))
+
+	info, ok = DescriptorFromBackstage(BackstageEntity{Kind: "Group", Metadata: BackstageMetadata{Name: "team-a"}, Spec: BackstageSpec{Parent: "org"}})
+	g.Expect(ok).To(BeTrue())
+	g.Expect(info.Type).To(Equal("team"))
+	g.Expect(info.Parents).To(Equal([]CortexTag{{Tag: "org"}}))
+
+	_, ok = DescriptorFromBackstage(BackstageEntity{Kind: "Location"})
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestBackstageRoundTrip(t *testing.T) {
+	g := NewWithT(t)
+
+	info := CortexInfo{
+		Tag:          "service1",
+		Title:        "Service 1",
+		Description:  "The first service",
+		Type:         "service",
+		Groups:       []string{"python"},
+		Owners:       []CortexOwner{{Type: "group", Name: "team-a"}},
+		Parents:      []CortexTag{{Tag: "payments"}},
+		Link:         []CortexLink{{Name: "Runbook", Type: "runbook", Url: "https://example.com/runbook"}},
+		Git:          CortexGit{Github: CortexGitRepository{Repository: "my-org/service1"}},
+		SourceFormat: "backstage",
+	}
+
+	// Every field is mapped, so the descriptor comes back unchanged
+	entity, unmapped := BackstageEntityFromDescriptor(info)
+	g.Expect(unmapped).To(BeEmpty())
+	back, ok := DescriptorFromBackstage(entity)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(back).To(Equal(info))
+
+	// An email owner is reported as unmapped, as it cannot come back
+	info.Owners = []CortexOwner{{Type: "email", Email: "jane.doe@example.com"}}
+	entity, unmapped = BackstageEntityFromDescriptor(info)
+	g.Expect(unmapped).To(Equal([]string{"x-cortex-owners"}))
+	back, ok = DescriptorFromBackstage(entity)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(back.Owners).To(BeNil())
+	g.Expect(back.ParseWarnings).To(Equal([]string{"spec.owner: user:jane.doe is not a group or an email"}))
+}
+
+func TestBackstageName(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(backstageName("team-a")).To(Equal("team-a"))
+	g.Expect(backstageName("jane.doe")).To(Equal("jane.doe"))
+	g.Expect(backstageName("Team A (EU)")).To(Equal("Team-A-EU"))
+	g.Expect(backstageName("--a__b..c")).To(Equal("a_b.c"))
+	g.Expect(backstageName("josé")).To(Equal("jos"))
+	g.Expect(backstageName("!!")).To(BeEmpty())
+	g.Expect(backstageName(strings.Repeat("a", 62) + ".b")).To(Equal(strings.Repeat("a", 62)))
+}
@@ -0,0 +1,202 @@
+package cortex
+
+import (
+	"sort"
+)
+
+const (
+	DependencyDirectionUpstream   = "upstream"
+	DependencyDirectionDownstream = "downstream"
+)
+
+// DependencyGraph is a directed graph of entity tags where an edge points
+// from the caller to the callee.
+type DependencyGraph struct {
+	Tags    []string
+	callees map[string][]string
+	callers map[string][]string
+}
+
+// NewDependencyGraph builds a graph from dependency edges. Multiple edges
+// between the same two entities, e.g. for different paths, are merged.
+func NewDependencyGraph(edges []CortexDependencyEdge) *DependencyGraph {
+	graph := &DependencyGraph{
+		callees: make(map[string][]string),
+		callers: make(map[string][]string),
+	}
+	tags := make(map[string]bool)
+	seen := make(map[[2]string]bool)
+	for _, edge := range edges {
+		tags[edge.CallerTag] = true
+		tags[edge.CalleeTag] = true
+		pair := [2]string{edge.CallerTag, edge.CalleeTag}
+		if seen[pair] {
+			continue
+		}
+		seen[pair] = true
+		graph.callees[edge.CallerTag] = append(graph.callees[edge.CallerTag], edge.CalleeTag)
+		graph.callers[edge.CalleeTag] = append(graph.callers[edge.CalleeTag], edge.CallerTag)
+	}
+	for tag := range tags {
+		graph.Tags = append(graph.Tags, tag)
+	}
+	sort.Strings(graph.Tags)
+	for _, neighbours := range graph.callees {
+		sort.Strings(neighbours)
+	}
+	for _, neighbours := range graph.callers {
+		sort.Strings(neighbours)
+	}
+	return graph
+}
+
+// Used to represent an entity reachable from another entity in the table
+type CortexDependencyClosureRow struct {
+	Tag        string
+	RelatedTag string
+	Direction  string
+	Hops       int
+	// Path is an example shortest path in call order, from caller to callee
+	Path []string
+}
+
+// isDependencyDirection is true for upstream and downstream.
+func isDependencyDirection(direction string) bool {
+	return direction == DependencyDirectionUpstream || direction == DependencyDirectionDownstream
+}
+
+// Closure returns every entity reachable from the tag, either upstream (the
+// entities it depends on) or downstream (the entities that depend on it),
+// with the shortest hop count found by a breadth first search. Any other
+// direction has no rows.
+func (g *DependencyGraph) Closure(tag string, direction string) []CortexDependencyClosureRow {
+	var neighbours map[string][]string
+	switch direction {
+	case DependencyDirectionUpstream:
+		neighbours = g.callees
+	case DependencyDirectionDownstream:
+		neighbours = g.callers
+	default:
+		return nil
+	}
+
+	var rows []CortexDependencyClosureRow
+	previous := map[string]string{tag: ""}
+	queue := []string{tag}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range neighbours[current] {
+			if _, ok := previous[next]; ok {
+				continue
+			}
+			previous[next] = current
+			queue = append(queue, next)
+
+			// Walk back to the start to build the path
+			path := []string{next}
+			for step := current; step != ""; step = previous[step] {
+				path = append(path, step)
+				if step == tag {
+					break
+				}
+			}
+			// The walk is from next back to tag, which is already in call
+			// order for downstream and needs reversing for upstream.
+			if direction == DependencyDirectionUpstream {
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+			}
+			rows = append(rows, CortexDependencyClosureRow{
+				Tag:        tag,
+				RelatedTag: next,
+				Direction:  direction,
+				Hops:       len(path) - 1,
+				Path:       path,
+			})
+		}
+	}
+	return rows
+}
+
+// Used to represent a dependency cycle in the table
+type CortexDependencyCycleRow struct {
+	CycleID int
+	Size    int
+	Tags    []string
+}
+
+// Cycles returns the strongly connected components of the graph which contain
+// a cycle, i.e. more than one entity or an entity depending on itself. They
+// are found with Tarjan's algorithm.
+func (g *DependencyGraph) Cycles() []CortexDependencyCycleRow {
+	index := 0
+	indices := make(map[string]int)
+	lowLinks := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+
+	var connect func(tag string)
+	connect = func(tag string) {
+		indices[tag] = index
+		lowLinks[tag] = index
+		index++
+		stack = append(stack, tag)
+		onStack[tag] = true
+
+		for _, next := range g.callees[tag] {
+			if _, visited := indices[next]; !visited {
+				connect(next)
+				lowLinks[tag] = min(lowLinks[tag], lowLinks[next])
+			} else if onStack[next] {
+				lowLinks[tag] = min(lowLinks[tag], indices[next])
+			}
+		}
+
+		if lowLinks[tag] == indices[tag] {
+			var component []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == tag {
+					break
+				}
+			}
+			components = append(components, component)
+		}
+	}
+
+	for _, tag := range g.Tags {
+		if _, visited := indices[tag]; !visited {
+			connect(tag)
+		}
+	}
+
+	var cycles [][]string
+	for _, component := range components {
+		if len(component) > 1 || g.hasSelfLoop(component[0]) {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+
+	rows := make([]CortexDependencyCycleRow, 0, len(cycles))
+	for i, cycle := range cycles {
+		rows = append(rows, CortexDependencyCycleRow{CycleID: i + 1, Size: len(cycle), Tags: cycle})
+	}
+	return rows
+}
+
+func (g *DependencyGraph) hasSelfLoop(tag string) bool {
+	for _, next := range g.callees[tag] {
+		if next == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package cortex
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+// a -> b -> c -> d, a -> c, c -> b (cycle b <-> c), e -> e (self loop)
+func testDependencyEdges() []CortexDependencyEdge {
+	return []CortexDependencyEdge{
+		{CallerTag: "a", CalleeTag: "b", Path: "/one"},
+		{CallerTag: "a", CalleeTag: "b", Path: "/two"},
+		{CallerTag: "b", CalleeTag: "c"},
+		{CallerTag: "c", CalleeTag: "d"},
+		{CallerTag: "a", CalleeTag: "c"},
+		{CallerTag: "c", CalleeTag: "b"},
+		{CallerTag: "e", CalleeTag: "e"},
+	}
+}
+
+func TestNewDependencyGraph(t *testing.T) {
+	g := NewWithT(t)
+	graph := NewDependencyGraph(testDependencyEdges())
+
+	g.Expect(graph.Tags).To(Equal([]string{"a", "b", "c", "d", "e"}))
+	g.Expect(graph.callees["a"]).To(Equal([]string{"b", "c"}))
+	g.Expect(graph.callers["c"]).To(Equal([]string{"a", "b"}))
+}
+
+func TestDependencyGraphClosureUpstream(t *testing.T) {
+	g := NewWithT(t)
+	graph := NewDependencyGraph(testDependencyEdges())
+
+	rows := graph.Closure("a", DependencyDirectionUpstream)
+	g.Expect(rows).To(Equal([]CortexDependencyClosureRow{
+		{Tag: "a", RelatedTag: "b", Direction: "upstream", Hops: 1, Path: []string{"a", "b"}},
+		{Tag: "a", RelatedTag: "c", Direction: "upstream", Hops: 1, Path: []string{"a", "c"}},
+		{Tag: "a", RelatedTag: "d", Direction: "upstream", Hops: 2, Path: []string{"a", "c", "d"}},
+	}))
+
+	g.Expect(graph.Closure("d", DependencyDirectionUpstream)).To(BeEmpty())
+}
+
+func TestDependencyGraphClosureDownstream(t *testing.T) {
+	g := NewWithT(t)
+	graph := NewDependencyGraph(testDependencyEdges())
+
+	rows := graph.Closure("d", DependencyDirectionDownstream)
+	g.Expect(rows).To(Equal([]CortexDependencyClosureRow{
+		{Tag: "d", RelatedTag: "c", Direction: "downstream", Hops: 1, Path: []string{"c", "d"}},
+		{Tag: "d", RelatedTag: "a", Direction: "downstream", Hops: 2, Path: []string{"a", "c", "d"}},
+		{Tag: "d", RelatedTag: "b", Direction: "downstream", Hops: 2, Path: []string{"b", "c", "d"}},
+	}))
+
+	// Unknown tags have no related entities
+	g.Expect(graph.Closure("unknown", DependencyDirectionDownstream)).To(BeEmpty())
+}
+
+func TestDependencyGraphClosureUnknownDirection(t *testing.T) {
+	g := NewWithT(t)
+	graph := NewDependencyGraph(testDependencyEdges())
+
+	// Rows are not mislabelled with the direction
+	g.Expect(graph.Closure("a", "sideways")).To(BeEmpty())
+	g.Expect(graph.Closure("d", "")).To(BeEmpty())
+
+	g.Expect(isDependencyDirection(DependencyDirectionUpstream)).To(BeTrue())
+	g.Expect(isDependencyDirection(DependencyDirectionDownstream)).To(BeTrue())
+	g.Expect(isDependencyDirection("sideways")).To(BeFalse())
+}
+
+func TestDependencyGraphCycles(t *testing.T) {
+	g := NewWithT(t)
+	graph := NewDependencyGraph(testDependencyEdges())
+
+	g.Expect(graph.Cycles()).To(Equal([]CortexDependencyCycleRow{
+		{CycleID: 1, Size: 2, Tags: []string{"b", "c"}},
+		{CycleID: 2, Size: 1, Tags: []string{"e"}},
+	}))
+
+	acyclic := NewDependencyGraph([]CortexDependencyEdge{{CallerTag: "a", CalleeTag: "b"}})
+	g.Expect(acyclic.Cycles()).To(BeEmpty())
+}
@@ -0,0 +1,220 @@
+package cortex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of the descriptors in the source_format column
+const (
+	DescriptorFormatCortex    = "cortex"
+	DescriptorFormatBackstage = "backstage"
+)
+
+// File names of descriptors found when a configured descriptor path is a
+// directory, including Backstage entity files
+var DescriptorFileNames = []string{"cortex.yaml", "cortex.yml", "catalog-info.yaml", "catalog-info.yml"}
+
+// listConfiguredDescriptors streams descriptors from local files if the
+// connection has descriptor_paths, and from the API otherwise.
+func listConfiguredDescriptors(ctx context.Context, config *SteampipeConfig, client *req.Client, writer HydratorWriter, asYAML bool) error {
+	if len(config.DescriptorPaths) > 0 {
+		return listLocalDescriptors(ctx, config.DescriptorPaths, writer, asYAML)
+	}
+	return listDescriptors(ctx, client, writer, asYAML)
+}
+
+// getConfiguredDescriptor returns the descriptor with the tag from local files
+// if the connection has descriptor_paths, and from the API otherwise. It is nil
+// if there is no such descriptor.
+func getConfiguredDescriptor(ctx context.Context, config *SteampipeConfig, client *req.Client, tag string, asYAML bool) (*Cortex, error) {
+	if len(config.DescriptorPaths) == 0 {
+		return getDescriptor(ctx, client, tag, asYAML)
+	}
+	descriptors := SliceWriter[CortexInfo]{Limit: math.MaxInt64}
+	if err := listLocalDescriptors(ctx, config.DescriptorPaths, &descriptors, asYAML); err != nil {
+		return nil, err
+	}
+	for _, info := range descriptors.Items {
+		if info.Tag == tag {
+			return &Cortex{Info: info}, nil
+		}
+	}
+	return nil, nil
+}
+
+// listLocalDescriptors streams the descriptors read from local files. Each path
+// is a file, a directory which is searched recursively for cortex.yaml files,
+// or a glob matching either.
+func listLocalDescriptors(ctx context.Context, paths []string, writer HydratorWriter, asYAML bool) error {
+	logger := plugin.Logger(ctx)
+
+	files, err := findDescriptorFiles(paths)
+	if err != nil {
+		return err
+	}
+	logger.Debug("listLocalDescriptors", "files", len(files))
+
+	for _, file := range files {
+		infos, err := readDescriptorFile(file)
+		if err != nil {
+			logger.Error("listLocalDescriptors", "file", file, "Error", err)
+			return err
+		}
+		for _, info := range infos {
+			if len(info.ParseWarnings) > 0 {
+				logger.Warn("listLocalDescriptors", "file", file, "tag", info.Tag, "ParseWarnings", info.ParseWarnings)
+			}
+			if !asYAML {
+				info.YAML = ""
+			}
+			// send the item to steampipe
+			writer.StreamListItem(ctx, info)
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if writer.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// findDescriptorFiles expands the configured paths into a sorted list of
+// descriptor files without duplicates. A path which matches no descriptor
+// files is an error, as it is most likely a typo in the connection config.
+func findDescriptorFiles(paths []string) ([]string, error) {
+	found := make(map[string]bool)
+	for _, pattern := range paths {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid descriptor path %q: %w", pattern, err)
+		}
+		matched := false
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() {
+				found[match] = true
+				matched = true
+				continue
+			}
+			err = filepath.WalkDir(match, func(path string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if entry.IsDir() {
+					// Skip hidden directories like .git
+					if path != match && entry.Name()[0] == '.' {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				for _, name := range DescriptorFileNames {
+					if entry.Name() == name {
+						found[path] = true
+						matched = true
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("descriptor path %q matches no descriptor files", pattern)
+		}
+	}
+
+	files := make([]string, 0, len(found))
+	for file := range found {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// readDescriptorFile parses a local descriptor, keeping the file name and text
+// in the info. A Backstage file returns each entity with a Cortex equivalent.
+// A file which is not valid YAML is returned with the error in ParseWarnings.
+func readDescriptorFile(file string) ([]CortexInfo, error) {
+	text, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := parseDescriptorDocuments(text)
+	if err != nil {
+		infos = []CortexInfo{{ParseWarnings: []string{err.Error()}}}
+	}
+	for i := range infos {
+		infos[i].File = file
+		infos[i].YAML = string(text)
+	}
+	return infos, nil
+}
+
+// parseDescriptorDocuments parses the text of a Cortex descriptor, or of a
+// Backstage file which may have many entities. An empty or comment only file is
+// a descriptor with a parse warning, so it is not mistaken for a valid one.
+func parseDescriptorDocuments(text []byte) ([]CortexInfo, error) {
+	var documents []*yaml.Node
+	decoder := yaml.NewDecoder(bytes.NewReader(text))
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, &document)
+	}
+
+	if len(documents) == 0 || isEmptyDocument(documents[0]) {
+		return []CortexInfo{{ParseWarnings: []string{"empty descriptor"}, SourceFormat: DescriptorFormatCortex}}, nil
+	}
+	if !isBackstageDocument(documents[0]) {
+		var descriptor Cortex
+		if err := documents[0].Decode(&descriptor); err != nil {
+			return nil, err
+		}
+		return []CortexInfo{descriptor.Info}, nil
+	}
+
+	var infos []CortexInfo
+	for _, document := range documents {
+		info, ok, err := parseBackstageDocument(document)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			infos = append(infos, info)
+		}
+	}
+	return infos, nil
+}
+
+// isEmptyDocument is true for a document with no content or only null, like
+// "---" on its own.
+func isEmptyDocument(document *yaml.Node) bool {
+	if len(document.Content) == 0 {
+		return true
+	}
+	content := document.Content[0]
+	return content.Kind == yaml.ScalarNode && content.Tag == "!!null"
+}
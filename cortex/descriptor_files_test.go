@@ -0,0 +1,173 @@
+package cortex
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	. "github.com/onsi/gomega"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/context_key"
+)
+
+// writeDescriptorFiles creates files relative to a temporary directory and
+// returns the directory.
+func writeDescriptorFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestFindDescriptorFiles(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := writeDescriptorFiles(t, map[string]string{
+		"repo/cortex.yaml":                  "",
+		"repo/services/a/cortex.yml":        "",
+		"repo/services/b/cortex.yaml":       "",
+		"repo/services/b/other.yaml":        "",
+		"repo/services/c/catalog-info.yaml": "",
+		"repo/.git/cortex.yaml":             "",
+		"extra/domain.yaml":                 "",
+		"extra/notes.txt":                   "",
+	})
+
+	files, err := findDescriptorFiles([]string{
+		filepath.Join(dir, "repo"),
+		filepath.Join(dir, "extra", "*.yaml"),
+		// Overlapping paths do not duplicate files
+		filepath.Join(dir, "repo", "cortex.yaml"),
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(files).To(Equal([]string{
+		filepath.Join(dir, "extra/domain.yaml"),
+		filepath.Join(dir, "repo/cortex.yaml"),
+		filepath.Join(dir, "repo/services/a/cortex.yml"),
+		filepath.Join(dir, "repo/services/b/cortex.yaml"),
+		filepath.Join(dir, "repo/services/c/catalog-info.yaml"),
+	}))
+
+	_, err = findDescriptorFiles([]string{"[invalid"})
+	g.Expect(err).ToNot(BeNil())
+
+	// A path which matches nothing, or a directory without descriptors, is an error
+	missing := filepath.Join(dir, "rpeo")
+	_, err = findDescriptorFiles([]string{filepath.Join(dir, "repo"), missing})
+	g.Expect(err).To(MatchError(`descriptor path "` + missing + `" matches no descriptor files`))
+	_, err = findDescriptorFiles([]string{filepath.Join(dir, "extra")})
+	g.Expect(err).ToNot(BeNil())
+}
+
+func TestListLocalDescriptors(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.WithValue(context.Background(), context_key.Logger, hclog.NewNullLogger())
+
+	text := "openapi: 3.0.1\ninfo:\n  title: Service 1\n  x-cortex-tag: service1\n"
+	dir := writeDescriptorFiles(t, map[string]string{"service1/cortex.yaml": text})
+
+	writer := NewSliceWriter[CortexInfo](100)
+	err := listLocalDescriptors(ctx, []string{dir}, writer, false)
+	g.Expect(err).To(BeNil())
+	g.Expect(writer.Items).To(HaveLen(1))
+	g.Expect(writer.Items[0].Tag).To(Equal("service1"))
+	g.Expect(writer.Items[0].File).To(Equal(filepath.Join(dir, "service1/cortex.yaml")))
+	g.Expect(writer.Items[0].YAML).To(BeEmpty())
+
+	writer = NewSliceWriter[CortexInfo](100)
+	err = listLocalDescriptors(ctx, []string{dir}, writer, true)
+	g.Expect(err).To(BeNil())
+	g.Expect(writer.Items[0].YAML).To(Equal(text))
+}
+
+func TestListLocalDescriptorsInvalid(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.WithValue(context.Background(), context_key.Logger, hclog.NewNullLogger())
+
+	dir := writeDescriptorFiles(t, map[string]string{
+		"a/cortex.yaml": "info: [not, a, map]",
+		"b/cortex.yaml": "info:\n  x-cortex-tag: [unclosed\n",
+		"c/cortex.yaml": "info:\n  x-cortex-tag: service3\n",
+		"d/cortex.yaml": "# TODO: describe the service\n",
+		"e/cortex.yaml": "---\n",
+	})
+
+	// Invalid files are still listed with their problems
+	writer := NewSliceWriter[CortexInfo](100)
+	err := listLocalDescriptors(ctx, []string{dir}, writer, false)
+	g.Expect(err).To(BeNil())
+	g.Expect(writer.Items).To(HaveLen(5))
+	g.Expect(writer.Items[0].File).To(Equal(filepath.Join(dir, "a/cortex.yaml")))
+	g.Expect(writer.Items[0].ParseWarnings).To(Equal([]string{"line 1: info must be a mapping"}))
+	g.Expect(writer.Items[1].File).To(Equal(filepath.Join(dir, "b/cortex.yaml")))
+	g.Expect(writer.Items[1].ParseWarnings).To(HaveLen(1))
+	g.Expect(writer.Items[1].ParseWarnings[0]).To(HavePrefix("yaml: line"))
+	g.Expect(writer.Items[2].Tag).To(Equal("service3"))
+	g.Expect(writer.Items[2].ParseWarnings).To(BeEmpty())
+	for _, item := range writer.Items[3:] {
+		g.Expect(item.Tag).To(BeEmpty())
+		g.Expect(item.ParseWarnings).To(Equal([]string{"empty descriptor"}))
+		g.Expect(item.SourceFormat).To(Equal(DescriptorFormatCortex))
+	}
+}
+
+func TestListLocalDescriptorsBackstage(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.WithValue(context.Background(), context_key.Logger, hclog.NewNullLogger())
+
+	dir := writeDescriptorFiles(t, map[string]string{
+		"service1/cortex.yaml": "info:\n  x-cortex-tag: service1\n",
+		"service2/catalog-info.yaml": `apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: service2
+spec:
+  type: service
+  owner: group:default/team-a
+---
+apiVersion: backstage.io/v1alpha1
+kind: Location
+metadata:
+  name: more
+spec:
+  targets: [./other.yaml]
+---
+apiVersion: backstage.io/v1alpha1
+kind: Resource
+metadata:
+  name: db2
+  tags: not-a-list
+spec:
+  type: database
+`,
+	})
+
+	writer := NewSliceWriter[CortexInfo](100)
+	err := listLocalDescriptors(ctx, []string{dir}, writer, false)
+	g.Expect(err).To(BeNil())
+	g.Expect(writer.Items).To(HaveLen(3))
+
+	g.Expect(writer.Items[0].Tag).To(Equal("service1"))
+	g.Expect(writer.Items[0].SourceFormat).To(Equal("cortex"))
+
+	// The Location has no Cortex equivalent
+	g.Expect(writer.Items[1].Tag).To(Equal("service2"))
+	g.Expect(writer.Items[1].SourceFormat).To(Equal("backstage"))
+	g.Expect(writer.Items[1].Type).To(Equal("service"))
+	g.Expect(writer.Items[1].Owners).To(Equal([]CortexOwner{{Type: "group", Name: "team-a"}}))
+	g.Expect(writer.Items[1].File).To(Equal(filepath.Join(dir, "service2/catalog-info.yaml")))
+	g.Expect(writer.Items[1].Raw).To(HaveKeyWithValue("kind", "Component"))
+
+	g.Expect(writer.Items[2].Tag).To(Equal("db2"))
+	g.Expect(writer.Items[2].Type).To(Equal("database"))
+	g.Expect(writer.Items[2].ParseWarnings).To(HaveLen(1))
+}
@@ -0,0 +1,132 @@
+package cortex
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	LintSeverityError   = "error"
+	LintSeverityWarning = "warning"
+)
+
+type DescriptorLintRule struct {
+	ID          string
+	Severity    string
+	Description string
+}
+
+var (
+	LintRuleMissingOwners     = DescriptorLintRule{"missing-owners", LintSeverityWarning, "The descriptor has no x-cortex-owners."}
+	LintRuleInvalidTag        = DescriptorLintRule{"invalid-tag", LintSeverityError, "The x-cortex-tag is missing or is not lower case letters, digits, '-', '_' and '.'."}
+	LintRuleUnknownParent     = DescriptorLintRule{"unknown-parent", LintSeverityError, "An x-cortex-parents tag does not exist."}
+	LintRuleUnknownDependency = DescriptorLintRule{"unknown-dependency", LintSeverityError, "An x-cortex-dependency tag does not exist."}
+	LintRuleDuplicateTag      = DescriptorLintRule{"duplicate-tag", LintSeverityError, "More than one descriptor has the same x-cortex-tag."}
+	LintRuleEmptySlackChannel = DescriptorLintRule{"empty-slack-channel", LintSeverityWarning, "An x-cortex-slack channel has no name."}
+	LintRuleMalformedLink     = DescriptorLintRule{"malformed-link", LintSeverityError, "An x-cortex-link url is not an absolute http or https URL."}
+)
+
+// DescriptorLintRules are all of the built-in rules, in the order they are checked.
+var DescriptorLintRules = []DescriptorLintRule{
+	LintRuleMissingOwners,
+	LintRuleInvalidTag,
+	LintRuleUnknownParent,
+	LintRuleUnknownDependency,
+	LintRuleDuplicateTag,
+	LintRuleEmptySlackChannel,
+	LintRuleMalformedLink,
+}
+
+var descriptorTagPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]*[a-z0-9])?$`)
+
+// Used to represent a single lint finding in the table
+type CortexDescriptorLintRow struct {
+	Tag          string
+	File         string
+	SourceFormat string
+	RuleID       string
+	Severity     string
+	Message      string
+}
+
+// LintDescriptors checks each descriptor against the built-in rules. Parents
+// and dependencies must be the tag of one of the descriptors or one of the
+// extra known tags, e.g. entities which only exist in the catalog.
+func LintDescriptors(descriptors []CortexInfo, knownTags []string) []CortexDescriptorLintRow {
+	exists := make(map[string]bool)
+	for _, tag := range knownTags {
+		exists[tag] = true
+	}
+	definedIn := make(map[string][]string)
+	for _, descriptor := range descriptors {
+		exists[descriptor.Tag] = true
+		definedIn[descriptor.Tag] = append(definedIn[descriptor.Tag], descriptor.File)
+	}
+
+	var rows []CortexDescriptorLintRow
+	for _, descriptor := range descriptors {
+		add := func(rule DescriptorLintRule, message string, args ...interface{}) {
+			rows = append(rows, CortexDescriptorLintRow{
+				Tag:          descriptor.Tag,
+				File:         descriptor.File,
+				SourceFormat: descriptor.SourceFormat,
+				RuleID:       rule.ID,
+				Severity:     rule.Severity,
+				Message:      fmt.Sprintf(message, args...),
+			})
+		}
+
+		if len(descriptor.Owners) == 0 {
+			add(LintRuleMissingOwners, "no owners")
+		}
+
+		if descriptor.Tag == "" {
+			add(LintRuleInvalidTag, "missing x-cortex-tag")
+		} else if !descriptorTagPattern.MatchString(descriptor.Tag) {
+			add(LintRuleInvalidTag, "tag %q must be lower case letters, digits, '-', '_' and '.'", descriptor.Tag)
+		}
+
+		for _, parent := range descriptor.Parents {
+			if !exists[parent.Tag] {
+				add(LintRuleUnknownParent, "parent %q does not exist", parent.Tag)
+			}
+		}
+
+		for _, dependency := range descriptor.Dependency.Cortex {
+			if !exists[dependency.Tag] {
+				add(LintRuleUnknownDependency, "dependency %q does not exist", dependency.Tag)
+			}
+		}
+
+		if files := definedIn[descriptor.Tag]; descriptor.Tag != "" && len(files) > 1 {
+			message := fmt.Sprintf("tag %q is defined by %d descriptors", descriptor.Tag, len(files))
+			if descriptor.File != "" {
+				message += ": " + strings.Join(files, ", ")
+			}
+			add(LintRuleDuplicateTag, "%s", message)
+		}
+
+		for i, channel := range descriptor.Slack.Channels {
+			if strings.TrimSpace(strings.TrimPrefix(channel.Name, "#")) == "" {
+				add(LintRuleEmptySlackChannel, "slack channel %d has no name", i)
+			}
+		}
+
+		for i, link := range descriptor.Link {
+			if !isAbsoluteHTTPURL(link.Url) {
+				add(LintRuleMalformedLink, "link %d (%s) has malformed url %q", i, link.Name, link.Url)
+			}
+		}
+	}
+	return rows
+}
+
+func isAbsoluteHTTPURL(value string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
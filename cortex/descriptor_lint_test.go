@@ -0,0 +1,47 @@
+package cortex
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLintDescriptors(t *testing.T) {
+	g := NewWithT(t)
+
+	owners := []CortexOwner{{Type: "group", Name: "team-a"}}
+	descriptors := []CortexInfo{
+		{
+			Tag:    "service1",
+			Owners: owners,
+			Parents: []CortexTag{
+				{Tag: "domain1"},
+				{Tag: "missing-domain"},
+			},
+			Dependency: CortexDependency{Cortex: []CortexDependencyCortex{{Tag: "service2"}, {Tag: "catalog-only"}, {Tag: "missing-service"}}},
+			Slack:      CortexSlack{Channels: []CortexSlackChannel{{Name: "service1"}, {Name: "#"}}},
+			Link: []CortexLink{
+				{Name: "Runbook", Url: "https://example.com/runbook"},
+				{Name: "Docs", Url: "example.com/docs"},
+			},
+		},
+		{Tag: "service2", File: "a/cortex.yaml"},
+		{Tag: "service2", File: "b/cortex.yaml", Owners: owners},
+		{Tag: "Domain_1!", Owners: owners},
+		{Tag: "domain1", Owners: owners},
+		{Owners: owners},
+	}
+
+	rows := LintDescriptors(descriptors, []string{"catalog-only"})
+	g.Expect(rows).To(Equal([]CortexDescriptorLintRow{
+		{Tag: "service1", RuleID: "unknown-parent", Severity: "error", Message: `parent "missing-domain" does not exist`},
+		{Tag: "service1", RuleID: "unknown-dependency", Severity: "error", Message: `dependency "missing-service" does not exist`},
+		{Tag: "service1", RuleID: "empty-slack-channel", Severity: "warning", Message: "slack channel 1 has no name"},
+		{Tag: "service1", RuleID: "malformed-link", Severity: "error", Message: `link 1 (Docs) has malformed url "example.com/docs"`},
+		{Tag: "service2", File: "a/cortex.yaml", RuleID: "missing-owners", Severity: "warning", Message: "no owners"},
+		{Tag: "service2", File: "a/cortex.yaml", RuleID: "duplicate-tag", Severity: "error", Message: `tag "service2" is defined by 2 descriptors: a/cortex.yaml, b/cortex.yaml`},
+		{Tag: "service2", File: "b/cortex.yaml", RuleID: "duplicate-tag", Severity: "error", Message: `tag "service2" is defined by 2 descriptors: a/cortex.yaml, b/cortex.yaml`},
+		{Tag: "Domain_1!", RuleID: "invalid-tag", Severity: "error", Message: `tag "Domain_1!" must be lower case letters, digits, '-', '_' and '.'`},
+		{RuleID: "invalid-tag", Severity: "error", Message: "missing x-cortex-tag"},
+	}))
+}
@@ -0,0 +1,275 @@
+package cortex
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SchemaViolation is a single failure of a value against a JSON schema.
+type SchemaViolation struct {
+	// JSON pointer (RFC 6901) to the failing value, "" for the root
+	Pointer string
+	// Schema keyword which failed, e.g. type, required or enum
+	Keyword  string
+	Expected string
+	Message  string
+}
+
+// ValidateSchema checks a decoded JSON value against a JSON schema and returns
+// every violation found. Only the commonly used keywords are supported: type,
+// required, properties, additionalProperties, items, enum, const, pattern and
+// the length, size and range limits. Unknown keywords, including $ref and the
+// combinators, are ignored.
+func ValidateSchema(schema map[string]interface{}, value interface{}) []SchemaViolation {
+	var violations []SchemaViolation
+	validateSchemaAt(schema, value, "", &violations)
+	return violations
+}
+
+func validateSchemaAt(schema map[string]interface{}, value interface{}, pointer string, violations *[]SchemaViolation) {
+	add := func(keyword string, expected string, message string, args ...interface{}) {
+		*violations = append(*violations, SchemaViolation{
+			Pointer:  pointer,
+			Keyword:  keyword,
+			Expected: expected,
+			Message:  fmt.Sprintf(message, args...),
+		})
+	}
+
+	// A value of the wrong type makes the other keywords meaningless
+	if types := schemaTypes(schema["type"]); len(types) > 0 {
+		actual := jsonType(value)
+		matched := false
+		for _, t := range types {
+			if t == actual || (t == "number" && actual == "integer") {
+				matched = true
+			}
+		}
+		if !matched {
+			expected := strings.Join(types, " or ")
+			add("type", expected, "expected %s but got %s", expected, actual)
+			return
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, option := range enum {
+			if jsonEqual(option, value) {
+				found = true
+			}
+		}
+		if !found {
+			expected := jsonString(enum)
+			add("enum", expected, "%s is not one of %s", jsonString(value), expected)
+		}
+	}
+	if constant, ok := schema["const"]; ok && !jsonEqual(constant, value) {
+		expected := jsonString(constant)
+		add("const", expected, "%s is not equal to %s", jsonString(value), expected)
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := len([]rune(v))
+		if limit, ok := schemaNumber(schema["minLength"]); ok && float64(length) < limit {
+			add("minLength", fmt.Sprintf(">= %v characters", limit), "string is %d characters, shorter than %v", length, limit)
+		}
+		if limit, ok := schemaNumber(schema["maxLength"]); ok && float64(length) > limit {
+			add("maxLength", fmt.Sprintf("<= %v characters", limit), "string is %d characters, longer than %v", length, limit)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err == nil && !re.MatchString(v) {
+				add("pattern", pattern, "%q does not match pattern %s", v, pattern)
+			}
+		}
+
+	case []interface{}:
+		if limit, ok := schemaNumber(schema["minItems"]); ok && float64(len(v)) < limit {
+			add("minItems", fmt.Sprintf(">= %v items", limit), "array has %d items, fewer than %v", len(v), limit)
+		}
+		if limit, ok := schemaNumber(schema["maxItems"]); ok && float64(len(v)) > limit {
+			add("maxItems", fmt.Sprintf("<= %v items", limit), "array has %d items, more than %v", len(v), limit)
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				validateSchemaAt(items, item, fmt.Sprintf("%s/%d", pointer, i), violations)
+			}
+		}
+
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, key := range required {
+				name, _ := key.(string)
+				if _, present := v[name]; !present {
+					*violations = append(*violations, SchemaViolation{
+						Pointer:  pointer + "/" + escapeJSONPointer(name),
+						Keyword:  "required",
+						Expected: schemaExpectedType(properties[name]),
+						Message:  fmt.Sprintf("required property %q is missing", name),
+					})
+				}
+			}
+		}
+
+		// Sorted so violations are returned in a stable order
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childPointer := pointer + "/" + escapeJSONPointer(key)
+			if propertySchema, ok := properties[key].(map[string]interface{}); ok {
+				validateSchemaAt(propertySchema, v[key], childPointer, violations)
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					*violations = append(*violations, SchemaViolation{
+						Pointer: childPointer,
+						Keyword: "additionalProperties",
+						Message: fmt.Sprintf("property %q is not allowed", key),
+					})
+				}
+			case map[string]interface{}:
+				validateSchemaAt(additional, v[key], childPointer, violations)
+			}
+		}
+
+	default:
+		if number, ok := schemaNumber(value); ok {
+			if limit, ok := schemaNumber(schema["minimum"]); ok && number < limit {
+				add("minimum", fmt.Sprintf(">= %v", limit), "%v is less than %v", number, limit)
+			}
+			if limit, ok := schemaNumber(schema["maximum"]); ok && number > limit {
+				add("maximum", fmt.Sprintf("<= %v", limit), "%v is greater than %v", number, limit)
+			}
+			if limit, ok := schemaNumber(schema["exclusiveMinimum"]); ok && number <= limit {
+				add("exclusiveMinimum", fmt.Sprintf("> %v", limit), "%v is not greater than %v", number, limit)
+			}
+			if limit, ok := schemaNumber(schema["exclusiveMaximum"]); ok && number >= limit {
+				add("exclusiveMaximum", fmt.Sprintf("< %v", limit), "%v is not less than %v", number, limit)
+			}
+		}
+	}
+}
+
+// schemaTypes returns the allowed types of a "type" keyword, which is either
+// a single type or a list of types.
+func schemaTypes(value interface{}) []string {
+	switch t := value.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var types []string
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func schemaExpectedType(schema interface{}) string {
+	if properties, ok := schema.(map[string]interface{}); ok {
+		return strings.Join(schemaTypes(properties["type"]), " or ")
+	}
+	return ""
+}
+
+// jsonType returns the JSON schema type of a decoded value. Whole numbers are
+// reported as integer, which also satisfies the number type.
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	if number, ok := schemaNumber(value); ok {
+		if number == math.Trunc(number) {
+			return "integer"
+		}
+		return "number"
+	}
+	return "object"
+}
+
+func schemaNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// jsonEqual compares two decoded values, treating numbers of different Go
+// types as equal if they have the same value, at any depth.
+func jsonEqual(a interface{}, b interface{}) bool {
+	na, aIsNumber := schemaNumber(a)
+	nb, bIsNumber := schemaNumber(b)
+	if aIsNumber || bIsNumber {
+		return aIsNumber && bIsNumber && na == nb
+	}
+	switch av := a.(type) {
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !jsonEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, value := range av {
+			other, ok := bv[key]
+			if !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func jsonString(value interface{}) string {
+	out, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(out)
+}
+
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,114 @@
+package cortex
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+)
+
+const testMetadataSchema = `
+type: object
+required: [cost_center, tier]
+properties:
+  cost_center:
+    type: string
+    pattern: "^CC-[0-9]+$"
+  tier:
+    type: integer
+    minimum: 1
+    maximum: 3
+  lifecycle:
+    enum: [alpha, beta, ga]
+  languages:
+    type: array
+    maxItems: 2
+    items:
+      type: string
+  limits:
+    type: object
+    additionalProperties: false
+    properties:
+      cpu:
+        type: number
+`
+
+func TestValidateSchema(t *testing.T) {
+	g := NewWithT(t)
+
+	var schema map[string]interface{}
+	g.Expect(yaml.Unmarshal([]byte(testMetadataSchema), &schema)).To(Succeed())
+
+	tests := []struct {
+		name     string
+		value    string
+		expected []SchemaViolation
+	}{
+		{
+			name:  "valid",
+			value: `{"cost_center": "CC-1", "tier": 2, "lifecycle": "ga", "languages": ["go"], "limits": {"cpu": 0.5}}`,
+		},
+		{
+			name:  "missing required",
+			value: `{"tier": 1}`,
+			expected: []SchemaViolation{
+				{Pointer: "/cost_center", Keyword: "required", Expected: "string", Message: `required property "cost_center" is missing`},
+			},
+		},
+		{
+			name:  "number stored as string",
+			value: `{"cost_center": "CC-1", "tier": "2"}`,
+			expected: []SchemaViolation{
+				{Pointer: "/tier", Keyword: "type", Expected: "integer", Message: "expected integer but got string"},
+			},
+		},
+		{
+			name:  "limits",
+			value: `{"cost_center": "finance", "tier": 4, "lifecycle": "deprecated", "languages": ["go", 1, "java"]}`,
+			expected: []SchemaViolation{
+				{Pointer: "/cost_center", Keyword: "pattern", Expected: "^CC-[0-9]+$", Message: `"finance" does not match pattern ^CC-[0-9]+$`},
+				{Pointer: "/languages", Keyword: "maxItems", Expected: "<= 2 items", Message: "array has 3 items, more than 2"},
+				{Pointer: "/languages/1", Keyword: "type", Expected: "string", Message: "expected string but got integer"},
+				{Pointer: "/lifecycle", Keyword: "enum", Expected: `["alpha","beta","ga"]`, Message: `"deprecated" is not one of ["alpha","beta","ga"]`},
+				{Pointer: "/tier", Keyword: "maximum", Expected: "<= 3", Message: "4 is greater than 3"},
+			},
+		},
+		{
+			name:  "additional properties",
+			value: `{"cost_center": "CC-1", "tier": 1, "limits": {"cpu": 1, "memory/max": "1Gi"}}`,
+			expected: []SchemaViolation{
+				{Pointer: "/limits/memory~1max", Keyword: "additionalProperties", Message: `property "memory/max" is not allowed`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var value interface{}
+			g.Expect(yaml.Unmarshal([]byte(tt.value), &value)).To(Succeed())
+			g.Expect(ValidateSchema(schema, value)).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestJSONEqual(t *testing.T) {
+	g := NewWithT(t)
+
+	// Numbers are compared by value at any depth
+	g.Expect(jsonEqual(1, 1.0)).To(BeTrue())
+	g.Expect(jsonEqual([]interface{}{[]interface{}{1}}, []interface{}{[]interface{}{1.0}})).To(BeTrue())
+	g.Expect(jsonEqual(map[string]interface{}{"a": []interface{}{2}}, map[string]interface{}{"a": []interface{}{2.0}})).To(BeTrue())
+
+	g.Expect(jsonEqual([]interface{}{1}, []interface{}{1, 2})).To(BeFalse())
+	g.Expect(jsonEqual([]interface{}{1}, []interface{}{"1"})).To(BeFalse())
+	g.Expect(jsonEqual(map[string]interface{}{"a": 1}, map[string]interface{}{"b": 1})).To(BeFalse())
+	g.Expect(jsonEqual(map[string]interface{}{"a": 1}, []interface{}{1})).To(BeFalse())
+
+	// Nested values in enum and const
+	var schema map[string]interface{}
+	g.Expect(yaml.Unmarshal([]byte("enum: [[1], {limits: [2]}]"), &schema)).To(Succeed())
+	g.Expect(ValidateSchema(schema, []interface{}{1.0})).To(BeEmpty())
+	g.Expect(ValidateSchema(schema, map[string]interface{}{"limits": []interface{}{2.0}})).To(BeEmpty())
+	g.Expect(ValidateSchema(schema, []interface{}{1.5})).To(HaveLen(1))
+}
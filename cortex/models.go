@@ -1,29 +1,238 @@
 package cortex
 
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
 type Cortex struct {
-	Openapi string     `yaml:"openapi"`
-	Info    CortexInfo `yaml:"info"`
+	Openapi string      `yaml:"openapi"`
+	Info    CortexInfo  `yaml:"info"`
+	Paths   CortexPaths `yaml:"paths,omitempty"`
+}
+
+// UnmarshalYAML decodes the descriptor and keeps the full original document
+// in Info.Raw. Values of the wrong type are recorded in Info.ParseWarnings
+// rather than failing, so one bad descriptor does not fail a whole page.
+func (c *Cortex) UnmarshalYAML(value *yaml.Node) error {
+	type plain Cortex
+	if err := value.Decode((*plain)(c)); err != nil {
+		typeErr, ok := err.(*yaml.TypeError)
+		if !ok {
+			return err
+		}
+		c.Info.ParseWarnings = append(c.Info.ParseWarnings, typeErr.Errors...)
+	}
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	c.Info.Raw = raw
+	c.Info.SourceFormat = DescriptorFormatCortex
+	return nil
 }
 
 type CortexInfo struct {
 	Tag   string `yaml:"x-cortex-tag"`
 	Title string `yaml:"title"`
 
-	Description    string                 `yaml:"description,omitempty"`
-	Type           string                 `yaml:"x-cortex-type,omitempty"`
-	Parents        []CortexTag            `yaml:"x-cortex-parents,omitempty"`
-	Groups         []string               `yaml:"x-cortex-groups,omitempty"`
-	Team           CortexTeam             `yaml:"x-cortex-team,omitempty"`
-	Owners         []CortexOwner          `yaml:"x-cortex-owners,omitempty"`
-	Slack          CortexSlack            `yaml:"x-cortex-slack,omitempty"`
-	Link           []CortexLink           `yaml:"x-cortex-link,omitempty"`
-	CustomMetadata map[string]interface{} `yaml:"x-cortex-custom-metadata,omitempty"`
-	Git            CortexGit              `yaml:"x-cortex-git,omitempty"`
-	Oncall         CortexOncall           `yaml:"x-cortex-oncall,omitempty"`
-	Issues         CortexIssues           `yaml:"x-cortex-issues,omitempty"`
-	Dependency     CortexDependency       `yaml:"x-cortex-dependency,omitempty"`
-	SLOs           CortexSLOs             `yaml:"x-cortex-slos,omitempty"`
-	StaticAnalysis CortexStaticAnalysis   `yaml:"x-cortex-static-analysis,omitempty"`
+	Description    string                        `yaml:"description,omitempty"`
+	Type           string                        `yaml:"x-cortex-type,omitempty"`
+	Parents        []CortexTag                   `yaml:"x-cortex-parents,omitempty"`
+	Groups         []string                      `yaml:"x-cortex-groups,omitempty"`
+	Team           CortexTeam                    `yaml:"x-cortex-team,omitempty"`
+	Owners         []CortexOwner                 `yaml:"x-cortex-owners,omitempty"`
+	Slack          CortexSlack                   `yaml:"x-cortex-slack,omitempty"`
+	Link           []CortexLink                  `yaml:"x-cortex-link,omitempty"`
+	CustomMetadata map[string]interface{}        `yaml:"x-cortex-custom-metadata,omitempty"`
+	Git            CortexGit                     `yaml:"x-cortex-git,omitempty"`
+	Oncall         CortexOncall                  `yaml:"x-cortex-oncall,omitempty"`
+	Issues         CortexIssues                  `yaml:"x-cortex-issues,omitempty"`
+	Dependency     CortexDependency              `yaml:"x-cortex-dependency,omitempty"`
+	SLOs           CortexSLOs                    `yaml:"x-cortex-slos,omitempty"`
+	StaticAnalysis CortexStaticAnalysis          `yaml:"x-cortex-static-analysis,omitempty"`
+	Apm            CortexApm                     `yaml:"x-cortex-apm,omitempty"`
+	Dashboards     CortexDashboards              `yaml:"x-cortex-dashboards,omitempty"`
+	Alerts         []CortexAlert                 `yaml:"x-cortex-alerts,omitempty"`
+	Sentry         CortexSentry                  `yaml:"x-cortex-sentry,omitempty"`
+	Bugsnag        CortexBugsnag                 `yaml:"x-cortex-bugsnag,omitempty"`
+	Rollbar        CortexRollbar                 `yaml:"x-cortex-rollbar,omitempty"`
+	Snyk           CortexSnyk                    `yaml:"x-cortex-snyk,omitempty"`
+	K8s            CortexK8s                     `yaml:"x-cortex-k8s,omitempty"`
+	Infra          CortexInfra                   `yaml:"x-cortex-infra,omitempty"`
+	CiCd           CortexCiCd                    `yaml:"x-cortex-ci-cd,omitempty"`
+	MicrosoftTeams []CortexMicrosoftTeamsChannel `yaml:"x-cortex-microsoft-teams,omitempty"`
+	CircleCI       CortexCircleCI                `yaml:"x-cortex-circle-ci,omitempty"`
+	FireHydrant    CortexFireHydrant             `yaml:"x-cortex-firehydrant,omitempty"`
+	IncidentIO     CortexIncidentIO              `yaml:"x-cortex-incident-io,omitempty"`
+	Rootly         CortexRootly                  `yaml:"x-cortex-rootly,omitempty"`
+	LaunchDarkly   CortexLaunchDarkly            `yaml:"x-cortex-launch-darkly,omitempty"`
+
+	// x-cortex-* keys which are not modelled above, e.g. from newer Cortex features
+	Extensions map[string]interface{} `yaml:"-"`
+	// The full descriptor this info was decoded from
+	Raw map[string]interface{} `yaml:"-"`
+	// The descriptor as YAML text, only set when requested
+	YAML string `yaml:"-"`
+	// Path of the local file the descriptor was read from, empty for the API
+	File string `yaml:"-"`
+	// Problems decoding the descriptor, the affected fields are left empty
+	ParseWarnings []string `yaml:"-"`
+	// Format the descriptor was read from, cortex or backstage
+	SourceFormat string `yaml:"-"`
+}
+
+// The field index of each modelled key of CortexInfo, anything else starting
+// with x-cortex- is kept in Extensions.
+var cortexInfoKeys = yamlKeys(reflect.TypeOf(CortexInfo{}))
+
+// UnmarshalYAML decodes each modelled field on its own and keeps any unknown
+// x-cortex-* keys in Extensions. A field which cannot be decoded is left empty
+// and the problem is added to ParseWarnings.
+func (i *CortexInfo) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.AliasNode {
+		value = value.Alias
+	}
+	if value.Kind != yaml.MappingNode {
+		return &yaml.TypeError{Errors: []string{fmt.Sprintf("line %d: info must be a mapping", value.Line)}}
+	}
+	fields := reflect.ValueOf(i).Elem()
+	entries, warnings := mappingEntries(value)
+	i.ParseWarnings = append(i.ParseWarnings, warnings...)
+	for _, entry := range entries {
+		key, node := entry[0].Value, entry[1]
+		if index, ok := cortexInfoKeys[key]; ok {
+			field := fields.Field(index)
+			if err := node.Decode(field.Addr().Interface()); err != nil {
+				field.Set(reflect.Zero(field.Type()))
+				i.ParseWarnings = append(i.ParseWarnings, key+": "+parseErrorMessage(err))
+			}
+			continue
+		}
+		if strings.HasPrefix(key, "x-cortex-") {
+			var extension interface{}
+			if err := node.Decode(&extension); err != nil {
+				i.ParseWarnings = append(i.ParseWarnings, key+": "+parseErrorMessage(err))
+				continue
+			}
+			if i.Extensions == nil {
+				i.Extensions = make(map[string]interface{})
+			}
+			i.Extensions[key] = extension
+		}
+	}
+	return nil
+}
+
+// mappingEntries returns the key and value nodes of a mapping with any merge
+// keys (<<) resolved. Keys in the mapping itself override merged keys, and
+// earlier merged mappings override later ones, as in the YAML merge spec. A
+// merge of anything other than mappings is skipped with a warning.
+func mappingEntries(mapping *yaml.Node) ([][2]*yaml.Node, []string) {
+	explicit := make(map[string]bool)
+	for k := 0; k+1 < len(mapping.Content); k += 2 {
+		if key := mapping.Content[k]; !isMergeKey(key) {
+			explicit[key.Value] = true
+		}
+	}
+
+	var entries [][2]*yaml.Node
+	var warnings []string
+	seen := make(map[string]bool)
+	for k := 0; k+1 < len(mapping.Content); k += 2 {
+		key, node := mapping.Content[k], mapping.Content[k+1]
+		if !isMergeKey(key) {
+			entries = append(entries, [2]*yaml.Node{key, node})
+			continue
+		}
+
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		merged := []*yaml.Node{node}
+		if node.Kind == yaml.SequenceNode {
+			merged = node.Content
+		}
+		for _, source := range merged {
+			if source.Kind == yaml.AliasNode {
+				source = source.Alias
+			}
+			if source.Kind != yaml.MappingNode {
+				warnings = append(warnings, fmt.Sprintf("line %d: cannot merge a value which is not a mapping", source.Line))
+				continue
+			}
+			sourceEntries, sourceWarnings := mappingEntries(source)
+			warnings = append(warnings, sourceWarnings...)
+			for _, entry := range sourceEntries {
+				if name := entry[0].Value; !explicit[name] && !seen[name] {
+					seen[name] = true
+					entries = append(entries, entry)
+				}
+			}
+		}
+	}
+	return entries, warnings
+}
+
+// isMergeKey is true for the << key of a YAML merge.
+func isMergeKey(key *yaml.Node) bool {
+	return key.Kind == yaml.ScalarNode && key.Value == "<<" && (key.Tag == "" || key.Tag == "!!merge" || key.Tag == "tag:yaml.org,2002:merge")
+}
+
+// parseErrorMessage returns the message of a decode error without the
+// "yaml: unmarshal errors" prefix.
+func parseErrorMessage(err error) string {
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		return strings.Join(typeErr.Errors, "; ")
+	}
+	return err.Error()
+}
+
+// MarshalYAML writes the extensions back after the modelled fields, so unknown
+// x-cortex-* keys survive a round trip.
+func (i CortexInfo) MarshalYAML() (interface{}, error) {
+	type plain CortexInfo
+	if len(i.Extensions) == 0 {
+		return plain(i), nil
+	}
+	var node yaml.Node
+	if err := node.Encode(plain(i)); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(i.Extensions))
+	for key := range i.Extensions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var value yaml.Node
+		if err := value.Encode(i.Extensions[key]); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, &value)
+	}
+	return &node, nil
+}
+
+// HasYAML is true if the descriptor was requested as YAML text.
+func (i CortexInfo) HasYAML() bool {
+	return i.YAML != ""
+}
+
+// yamlKeys returns the field index of each yaml key of a struct type.
+func yamlKeys(t reflect.Type) map[string]int {
+	keys := make(map[string]int)
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if name != "" && name != "-" {
+			keys[name] = i
+		}
+	}
+	return keys
 }
 
 type CortexTag struct {
@@ -31,12 +240,16 @@ type CortexTag struct {
 }
 
 type CortexOwner struct {
+	// Type is group, email or slack
 	Type        string `yaml:"type"`
 	Name        string `yaml:"name,omitempty"`
 	Provider    string `yaml:"provider,omitempty"`
 	Email       string `yaml:"email,omitempty"`
 	Inheritance string `yaml:"inheritance,omitempty"`
 	Description string `yaml:"description,omitempty"`
+	// Channel and NotificationsEnabled are only used by slack owners
+	Channel              string `yaml:"channel,omitempty"`
+	NotificationsEnabled bool   `yaml:"notificationsEnabled,omitempty"`
 }
 
 type CortexTeam struct {
@@ -72,30 +285,72 @@ type CortexLink struct {
 	Url  string `yaml:"url"`
 }
 type CortexGit struct {
-	Github CortexGithub `yaml:"github"`
+	Github    CortexGitRepository `yaml:"github,omitempty"`
+	Gitlab    CortexGitRepository `yaml:"gitlab,omitempty"`
+	Azure     CortexGitRepository `yaml:"azure,omitempty"`
+	Bitbucket CortexGitRepository `yaml:"bitbucket,omitempty"`
 }
 
-type CortexGithub struct {
+type CortexGitRepository struct {
+	// Project is only used by Azure DevOps
+	Project    string `yaml:"project,omitempty"`
 	Repository string `yaml:"repository"`
 	BasePath   string `yaml:"basepath,omitempty"`
 	Alias      string `yaml:"alias,omitempty"`
 }
 
+// Normalize returns the configured git repository in the same shape as the
+// entity API, whichever provider it is configured for.
+func (g CortexGit) Normalize() CortexEntityGit {
+	providers := []struct {
+		Name       string
+		Repository CortexGitRepository
+	}{
+		{"github", g.Github},
+		{"gitlab", g.Gitlab},
+		{"azure", g.Azure},
+		{"bitbucket", g.Bitbucket},
+	}
+	for _, provider := range providers {
+		repo := provider.Repository
+		if repo.Repository == "" {
+			continue
+		}
+		if repo.Project != "" {
+			repo.Repository = repo.Project + "/" + repo.Repository
+		}
+		return CortexEntityGit{
+			Provider:   provider.Name,
+			Repository: repo.Repository,
+			BasePath:   repo.BasePath,
+			Alias:      repo.Alias,
+		}.Normalize()
+	}
+	return CortexEntityGit{}
+}
+
 type CortexOncall struct {
-	VictorOps CortexOncallVictorOps `yaml:"victorops"`
+	PagerDuty CortexOncallProvider `yaml:"pagerduty,omitempty"`
+	OpsGenie  CortexOncallProvider `yaml:"opsgenie,omitempty"`
+	VictorOps CortexOncallProvider `yaml:"victorops,omitempty"`
+	XMatters  CortexOncallProvider `yaml:"xmatters,omitempty"`
 }
 
-type CortexOncallVictorOps struct {
-	Type string `yaml:"type"`
-	ID   string `yaml:"id"`
+type CortexOncallProvider struct {
+	// Type is e.g. SERVICE, SCHEDULE or ESCALATION_POLICY
+	Type string `yaml:"type,omitempty"`
+	ID   string `yaml:"id,omitempty"`
 }
 
 type CortexIssues struct {
-	Jira CortexIssuesJira `yaml:"jira"`
+	Jira CortexIssuesJira `yaml:"jira,omitempty"`
 }
 
 type CortexIssuesJira struct {
-	Projects []string `yaml:"projects"`
+	Projects   []string `yaml:"projects,omitempty"`
+	Labels     []string `yaml:"labels,omitempty"`
+	Components []string `yaml:"components,omitempty"`
+	DefaultJQL string   `yaml:"defaultJql,omitempty"`
 }
 
 type CortexDependency struct {
@@ -103,29 +358,6 @@ type CortexDependency struct {
 	AWS    CortexDependencyAWS      `yaml:"aws,omitempty"`
 }
 
-// UnmarshalYAML implements custom unmarshaling to gracefully handle malformed dependency data
-func (cd *CortexDependency) UnmarshalYAML(unmarshal func(interface{}) error) error {
-	// Create a temporary type to avoid infinite recursion
-	type rawDependency struct {
-		Cortex []CortexDependencyCortex `yaml:"cortex,omitempty"`
-		AWS    CortexDependencyAWS      `yaml:"aws,omitempty"`
-	}
-
-	var raw rawDependency
-	if err := unmarshal(&raw); err != nil {
-		// Log warning but don't fail - set empty defaults
-		// The error will be silently handled, allowing other fields to process
-		cd.Cortex = []CortexDependencyCortex{}
-		cd.AWS = CortexDependencyAWS{Tags: []Tag{}}
-		return nil
-	}
-
-	// Successfully unmarshaled, populate the struct
-	cd.Cortex = raw.Cortex
-	cd.AWS = raw.AWS
-	return nil
-}
-
 type CortexDependencyCortex struct {
 	Tag         string `yaml:"tag"`
 	Path        string `yaml:"path,omitempty"`
@@ -134,11 +366,17 @@ type CortexDependencyCortex struct {
 }
 
 type CortexDependencyAWS struct {
-	Tags []Tag `yaml:"tags"`
+	Tags []Tag `yaml:"tags,omitempty"`
 }
 
 type CortexSLOs struct {
-	NewRelic []CortexSLO `yaml:"newrelic"`
+	Datadog    []CortexSLO           `yaml:"datadog,omitempty"`
+	Dynatrace  []CortexSLO           `yaml:"dynatrace,omitempty"`
+	Lightstep  []CortexSLOLightstep  `yaml:"lightstep,omitempty"`
+	NewRelic   []CortexSLO           `yaml:"newrelic,omitempty"`
+	Prometheus []CortexSLOPrometheus `yaml:"prometheus,omitempty"`
+	SignalFx   []CortexSLOSignalFx   `yaml:"signalfx,omitempty"`
+	SumoLogic  []CortexSLO           `yaml:"sumologic,omitempty"`
 }
 
 type CortexSLO struct {
@@ -146,16 +384,304 @@ type CortexSLO struct {
 	Alias string `yaml:"alias,omitempty"`
 }
 
+type CortexSLOLightstep struct {
+	StreamID string `yaml:"streamId"`
+	// Latency and error rate targets, keyed by target kind
+	Targets map[string]interface{} `yaml:"targets,omitempty"`
+}
+
+type CortexSLOPrometheus struct {
+	ErrorQuery string  `yaml:"errorQuery"`
+	TotalQuery string  `yaml:"totalQuery"`
+	SLO        float64 `yaml:"slo"`
+	Name       string  `yaml:"name,omitempty"`
+	Alias      string  `yaml:"alias,omitempty"`
+}
+
+type CortexSLOSignalFx struct {
+	Query     string  `yaml:"query"`
+	Rollup    string  `yaml:"rollup,omitempty"`
+	Target    float64 `yaml:"target"`
+	Lookback  string  `yaml:"lookback,omitempty"`
+	Operation string  `yaml:"operation,omitempty"`
+}
+
 type Tag struct {
 	Key   string `yaml:"key"`
 	Value string `yaml:"value"`
 }
 
 type CortexStaticAnalysis struct {
-	Sonarqube CortexStaticAnalysisSonarqube `yaml:"sonarqube"`
+	Sonarqube CortexStaticAnalysisSonarqube `yaml:"sonarqube,omitempty"`
+	Codecov   CortexStaticAnalysisCodecov   `yaml:"codecov,omitempty"`
+	Mend      CortexStaticAnalysisMend      `yaml:"mend,omitempty"`
+	Veracode  CortexStaticAnalysisVeracode  `yaml:"veracode,omitempty"`
+	Checkmarx CortexStaticAnalysisCheckmarx `yaml:"checkmarx,omitempty"`
 }
 
 type CortexStaticAnalysisSonarqube struct {
 	Project string `yaml:"project"`
 	Alias   string `yaml:"alias,omitempty"`
 }
+
+type CortexStaticAnalysisCodecov struct {
+	Owner    string `yaml:"owner,omitempty"`
+	Repo     string `yaml:"repo"`
+	Provider string `yaml:"provider,omitempty"`
+	Flag     string `yaml:"flag,omitempty"`
+}
+
+type CortexStaticAnalysisMend struct {
+	ApplicationIDs []string `yaml:"applicationIds,omitempty"`
+	ProjectIDs     []string `yaml:"projectIds,omitempty"`
+}
+
+type CortexStaticAnalysisVeracode struct {
+	ApplicationNames []string                `yaml:"applicationNames,omitempty"`
+	Sandboxes        []CortexVeracodeSandbox `yaml:"sandboxes,omitempty"`
+}
+
+type CortexVeracodeSandbox struct {
+	ApplicationName string `yaml:"applicationName"`
+	SandboxName     string `yaml:"sandboxName"`
+}
+
+type CortexStaticAnalysisCheckmarx struct {
+	Projects []CortexCheckmarxProject `yaml:"projects,omitempty"`
+}
+
+type CortexCheckmarxProject struct {
+	ProjectID   int64  `yaml:"projectId,omitempty"`
+	ProjectName string `yaml:"projectName,omitempty"`
+}
+
+type CortexApm struct {
+	Datadog     CortexApmDatadog     `yaml:"datadog,omitempty"`
+	NewRelic    CortexApmNewRelic    `yaml:"newrelic,omitempty"`
+	Dynatrace   CortexApmDynatrace   `yaml:"dynatrace,omitempty"`
+	AppDynamics CortexApmAppDynamics `yaml:"appdynamics,omitempty"`
+}
+
+type CortexApmDatadog struct {
+	Monitors    []int64 `yaml:"monitors,omitempty"`
+	ServiceTags []Tag   `yaml:"serviceTags,omitempty"`
+}
+
+type CortexApmNewRelic struct {
+	Applications []CortexApmApplication `yaml:"applications,omitempty"`
+	Tags         []CortexApmNewRelicTag `yaml:"tags,omitempty"`
+}
+
+type CortexApmApplication struct {
+	ApplicationID int64  `yaml:"applicationId"`
+	Alias         string `yaml:"alias,omitempty"`
+}
+
+type CortexApmNewRelicTag struct {
+	Tag   string `yaml:"tag"`
+	Value string `yaml:"value"`
+	Alias string `yaml:"alias,omitempty"`
+}
+
+type CortexApmDynatrace struct {
+	EntityIDs          []string `yaml:"entityIds,omitempty"`
+	EntityNameMatchers []string `yaml:"entityNameMatchers,omitempty"`
+}
+
+type CortexApmAppDynamics struct {
+	Applications []CortexApmApplication `yaml:"applications,omitempty"`
+}
+
+type CortexDashboards struct {
+	Embeds []CortexDashboardEmbed `yaml:"embeds,omitempty"`
+}
+
+type CortexDashboardEmbed struct {
+	// Type is e.g. grafana, datadog or newrelic
+	Type string `yaml:"type"`
+	Url  string `yaml:"url"`
+}
+
+type CortexAlert struct {
+	// Type is the alerting integration, e.g. opsgenie
+	Type  string `yaml:"type"`
+	Tag   string `yaml:"tag"`
+	Value string `yaml:"value"`
+}
+
+type CortexSentry struct {
+	Projects []CortexProjectName `yaml:"projects,omitempty"`
+}
+
+type CortexProjectName struct {
+	Name string `yaml:"name"`
+}
+
+type CortexBugsnag struct {
+	Project string `yaml:"project,omitempty"`
+}
+
+type CortexRollbar struct {
+	Project string `yaml:"project,omitempty"`
+}
+
+type CortexSnyk struct {
+	Projects []CortexSnykProject `yaml:"projects,omitempty"`
+}
+
+type CortexSnykProject struct {
+	Organization string `yaml:"organization"`
+	ProjectID    string `yaml:"projectId"`
+	Source       string `yaml:"source,omitempty"`
+}
+
+type CortexK8s struct {
+	Deployment  []CortexK8sResource `yaml:"deployment,omitempty"`
+	ArgoRollout []CortexK8sResource `yaml:"argorollout,omitempty"`
+	StatefulSet []CortexK8sResource `yaml:"statefulset,omitempty"`
+	CronJob     []CortexK8sResource `yaml:"cronjob,omitempty"`
+}
+
+type CortexK8sResource struct {
+	// Identifier is namespace/name
+	Identifier string `yaml:"identifier"`
+	Cluster    string `yaml:"cluster,omitempty"`
+}
+
+type CortexInfra struct {
+	AWS CortexInfraAWS `yaml:"aws,omitempty"`
+}
+
+type CortexInfraAWS struct {
+	ECS          []CortexInfraECS          `yaml:"ecs,omitempty"`
+	CloudControl []CortexInfraCloudControl `yaml:"cloudControl,omitempty"`
+}
+
+type CortexInfraECS struct {
+	ClusterArn string `yaml:"clusterArn"`
+	ServiceArn string `yaml:"serviceArn"`
+}
+
+type CortexInfraCloudControl struct {
+	Type       string `yaml:"type"`
+	Region     string `yaml:"region"`
+	AccountID  string `yaml:"accountId"`
+	Identifier string `yaml:"identifier"`
+}
+
+type CortexCiCd struct {
+	Buildkite CortexCiCdBuildkite `yaml:"buildkite,omitempty"`
+}
+
+type CortexCiCdBuildkite struct {
+	Pipelines []CortexBuildkitePipeline `yaml:"pipelines,omitempty"`
+	Tags      []CortexTag               `yaml:"tags,omitempty"`
+}
+
+type CortexBuildkitePipeline struct {
+	Slug string `yaml:"slug"`
+}
+
+type CortexMicrosoftTeamsChannel struct {
+	Name                 string `yaml:"name"`
+	NotificationsEnabled bool   `yaml:"notificationsEnabled"`
+	Description          string `yaml:"description,omitempty"`
+}
+
+type CortexCircleCI struct {
+	Projects []CortexCircleCIProject `yaml:"projects,omitempty"`
+}
+
+type CortexCircleCIProject struct {
+	ProjectSlug string `yaml:"projectSlug"`
+	Alias       string `yaml:"alias,omitempty"`
+}
+
+type CortexFireHydrant struct {
+	Services []CortexFireHydrantService `yaml:"services,omitempty"`
+}
+
+type CortexFireHydrantService struct {
+	Identifier string `yaml:"identifier"`
+	// IdentifierType is ID or SLUG
+	IdentifierType string `yaml:"identifierType,omitempty"`
+}
+
+type CortexIncidentIO struct {
+	CustomFields []CortexIncidentIOCustomField `yaml:"customFields,omitempty"`
+}
+
+// A custom field is identified by either its name or its id
+type CortexIncidentIOCustomField struct {
+	Name  string `yaml:"name,omitempty"`
+	ID    string `yaml:"id,omitempty"`
+	Value string `yaml:"value"`
+	Alias string `yaml:"alias,omitempty"`
+}
+
+type CortexRootly struct {
+	Services []CortexRootlyService `yaml:"services,omitempty"`
+}
+
+// A service is identified by either its id or its slug
+type CortexRootlyService struct {
+	ID    string `yaml:"id,omitempty"`
+	Slug  string `yaml:"slug,omitempty"`
+	Alias string `yaml:"alias,omitempty"`
+}
+
+type CortexLaunchDarkly struct {
+	Projects []CortexLaunchDarklyProject `yaml:"projects,omitempty"`
+}
+
+// A project is identified by either its key or a tag
+type CortexLaunchDarklyProject struct {
+	Key          string                          `yaml:"key,omitempty"`
+	Tag          string                          `yaml:"tag,omitempty"`
+	Environments []CortexLaunchDarklyEnvironment `yaml:"environments,omitempty"`
+	Alias        string                          `yaml:"alias,omitempty"`
+}
+
+type CortexLaunchDarklyEnvironment struct {
+	EnvironmentName string `yaml:"environmentName"`
+}
+
+// CortexPaths maps each path of an OpenAPI document to its operations.
+type CortexPaths map[string]CortexPathItem
+
+// CortexPathItem maps a lower case HTTP method to its operation.
+type CortexPathItem map[string]CortexOperation
+
+// OpenAPIMethods are the HTTP methods a path item can have an operation for, in
+// the order the OpenAPI specification lists them.
+var OpenAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// UnmarshalYAML keeps only the operations of a path item. Other fields, like
+// summary and parameters, are shared by all operations and are ignored.
+func (p *CortexPathItem) UnmarshalYAML(value *yaml.Node) error {
+	var fields map[string]yaml.Node
+	if err := value.Decode(&fields); err != nil {
+		return err
+	}
+	*p = make(CortexPathItem)
+	for _, method := range OpenAPIMethods {
+		node, ok := fields[method]
+		if !ok {
+			continue
+		}
+		var operation CortexOperation
+		if err := node.Decode(&operation); err != nil {
+			return err
+		}
+		(*p)[method] = operation
+	}
+	return nil
+}
+
+type CortexOperation struct {
+	OperationID string   `yaml:"operationId,omitempty"`
+	Summary     string   `yaml:"summary,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+	Deprecated  bool     `yaml:"deprecated,omitempty"`
+}
@@ -14,6 +14,10 @@ const DefaultBaseURL = "https://api.getcortexapp.com"
 type SteampipeConfig struct {
 	ApiKey  *string `cty:"api_key"`
 	BaseURL *string `cty:"base_url"`
+	// Read descriptors from these local files, directories or globs instead of the API
+	DescriptorPaths []string `cty:"descriptor_paths"`
+	// Local git checkout to read the history of descriptors from
+	DescriptorRepository *string `cty:"descriptor_repository"`
 }
 
 func NewSteampipeConfig(token, url string) *SteampipeConfig {
@@ -51,14 +55,37 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 				return NewSteampipeConfig("", DefaultBaseURL)
 			},
 			Schema: map[string]*schema.Attribute{
-				"api_key": {Type: schema.TypeString},
+				"api_key":               {Type: schema.TypeString},
+				"descriptor_paths":      {Type: schema.TypeList, Elem: &schema.Attribute{Type: schema.TypeString}},
+				"descriptor_repository": {Type: schema.TypeString},
 			},
 		},
 		TableMap: map[string]*plugin.Table{
-			"cortex_descriptor":      tableCortexDescriptor(),
-			"cortex_entity":          tableCortexEntity(),
-			"cortex_team":            tableCortexTeam(),
-			"cortex_scorecard_score": tableCortexScorecardScore(),
+			"cortex_audit_log":               tableCortexAuditLog(),
+			"cortex_custom_data":             tableCortexCustomData(),
+			"cortex_custom_event":            tableCortexCustomEvent(),
+			"cortex_dependency":              tableCortexDependency(),
+			"cortex_dependency_closure":      tableCortexDependencyClosure(),
+			"cortex_dependency_cycle":        tableCortexDependencyCycle(),
+			"cortex_deploy":                  tableCortexDeploy(),
+			"cortex_descriptor":              tableCortexDescriptor(),
+			"cortex_descriptor_backstage":    tableCortexDescriptorBackstage(),
+			"cortex_descriptor_history":      tableCortexDescriptorHistory(),
+			"cortex_descriptor_lint":         tableCortexDescriptorLint(),
+			"cortex_entity":                  tableCortexEntity(),
+			"cortex_entity_api_operation":    tableCortexEntityApiOperation(),
+			"cortex_entity_drift":            tableCortexEntityDrift(),
+			"cortex_entity_metadata":         tableCortexEntityMetadata(),
+			"cortex_entity_relationship":     tableCortexEntityRelationship(),
+			"cortex_entity_schema_violation": tableCortexEntitySchemaViolation(),
+			"cortex_entity_type":             tableCortexEntityType(),
+			"cortex_gitops_log":              tableCortexGitopsLog(),
+			"cortex_oncall":                  tableCortexOncall(),
+			"cortex_package":                 tableCortexPackage(),
+			"cortex_relationship_type":       tableCortexRelationshipType(),
+			"cortex_slack_channel":           tableCortexSlackChannel(),
+			"cortex_team":                    tableCortexTeam(),
+			"cortex_scorecard_score":         tableCortexScorecardScore(),
 		},
 	}
 	return p
@@ -0,0 +1,129 @@
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type CortexAuditLogResponse struct {
+	Logs       []CortexAuditLog `yaml:"logs"`
+	Page       int              `yaml:"page"`
+	TotalPages int              `yaml:"totalPages"`
+	Total      int              `yaml:"total"`
+}
+
+type CortexAuditLog struct {
+	Action                string `yaml:"action"`
+	ActorEmail            string `yaml:"actorEmail"`
+	ActorType             string `yaml:"actorType"`
+	ActorApiKeyIdentifier string `yaml:"actorApiKeyIdentifier"`
+	ActorIPAddress        string `yaml:"actorIpAddress"`
+	ActorRequestType      string `yaml:"actorRequestType"`
+	ObjectType            string `yaml:"objectType"`
+	ObjectIdentifier      string `yaml:"objectIdentifier"`
+	ObjectName            string `yaml:"objectName"`
+	Timestamp             string `yaml:"timestamp"`
+
+	// Any other fields describing the change
+	Details map[string]interface{} `yaml:",inline"`
+}
+
+// Actor is the email of the user, falling back to the API key identifier.
+func (l *CortexAuditLog) Actor() string {
+	return firstNonEmpty(l.ActorEmail, l.ActorApiKeyIdentifier)
+}
+
+func tableCortexAuditLog() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_audit_log",
+		Description: "Cortex audit logs api.",
+		List: &plugin.ListConfig{
+			Hydrate: listAuditLogsHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "timestamp", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "timestamp", Type: proto.ColumnType_TIMESTAMP, Description: "Time of the change."},
+			{Name: "actor", Type: proto.ColumnType_STRING, Description: "Email of the user, or identifier of the API key, that made the change.", Transform: transform.FromP(transform.MethodValue, "Actor")},
+			{Name: "actor_type", Type: proto.ColumnType_STRING, Description: "Type of the actor, e.g. PERSON or API_KEY."},
+			{Name: "actor_request_type", Type: proto.ColumnType_STRING, Description: "How the change was made, e.g. API_KEY or WEB."},
+			{Name: "action", Type: proto.ColumnType_STRING, Description: "Action, e.g. CREATE, UPDATE or DELETE."},
+			{Name: "object_type", Type: proto.ColumnType_STRING, Description: "Type of the changed object, e.g. CATALOG or SCORECARD."},
+			{Name: "object_identifier", Type: proto.ColumnType_STRING, Description: "Identifier, usually the tag, of the changed object."},
+			{Name: "object_name", Type: proto.ColumnType_STRING, Description: "Name of the changed object."},
+			{Name: "ip_address", Type: proto.ColumnType_IPADDR, Description: "IP address of the actor.", Transform: transform.FromField("ActorIPAddress").NullIfZero()},
+			{Name: "details", Type: proto.ColumnType_JSON, Description: "Other fields describing the change.", Transform: transform.FromField("Details")},
+		},
+	}
+}
+
+func listAuditLogsHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+
+	var timeRange TimeRange
+	if d.Quals["timestamp"] != nil {
+		timeRange = timeRangeFromQuals(d.Quals["timestamp"].Quals)
+	}
+
+	logger.Info("listAuditLogsHydrator", "timeRange", timeRange)
+	return nil, listAuditLogs(ctx, client, &hydratorWriter, timeRange)
+}
+
+func listAuditLogs(ctx context.Context, client *req.Client, writer HydratorWriter, timeRange TimeRange) error {
+	logger := plugin.Logger(ctx)
+
+	var response CortexAuditLogResponse
+	var page int = 0
+	for {
+		logger.Debug("listAuditLogs", "page", page)
+		resp := client.
+			Get("/api/v1/audit-logs").
+			// Filters
+			SetQueryParams(timeRange.QueryParams("startTime", "endTime")).
+			// Pagination
+			SetQueryParam("pageSize", "1000").
+			SetQueryParam("page", strconv.Itoa(page)).
+			Do(ctx)
+
+		// Check for HTTP errors
+		if resp.IsErrorState() {
+			logger.Error("listAuditLogs", "Status", resp.Status, "Body", resp.String())
+			return fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+		}
+
+		// Unmarshal the response and check for unmarshal errors
+		err := resp.Into(&response)
+		if err != nil {
+			logger.Error("listAuditLogs", "page", page, "Error", err)
+			return err
+		}
+
+		logger.Debug("listAuditLogs", "totalPages", response.TotalPages, "total", response.Total)
+
+		for _, result := range response.Logs {
+			// send the item to steampipe
+			writer.StreamListItem(ctx, result)
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if writer.RowsRemaining(ctx) == 0 {
+				logger.Debug("listAuditLogs", "RowsRemaining", writer.RowsRemaining(ctx))
+				return nil
+			}
+		}
+		page++
+		if page >= response.TotalPages {
+			logger.Debug("listAuditLogs", "page", page, "totalPages", response.TotalPages)
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"gopkg.in/yaml.v3"
+)
+
+func prepareAuditLogResponse(t *testing.T, logs []CortexAuditLog, page, totalPages, total int) []byte {
+	t.Helper()
+	response := CortexAuditLogResponse{
+		Logs:       logs,
+		Page:       page,
+		TotalPages: totalPages,
+		Total:      total,
+	}
+	responseBytes, err := yaml.Marshal(response)
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	return responseBytes
+}
+
+func TestTableCortexAuditLog(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexAuditLog()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_audit_log"))
+	g.Expect(table.Description).To(Equal("Cortex audit logs api."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(1))
+	g.Expect(table.List.KeyColumns[0].Name).To(Equal("timestamp"))
+	g.Expect(table.List.KeyColumns[0].Require).To(Equal(plugin.Optional))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"timestamp", proto.ColumnType_TIMESTAMP},
+		{"actor", proto.ColumnType_STRING},
+		{"actor_type", proto.ColumnType_STRING},
+		{"actor_request_type", proto.ColumnType_STRING},
+		{"action", proto.ColumnType_STRING},
+		{"object_type", proto.ColumnType_STRING},
+		{"object_identifier", proto.ColumnType_STRING},
+		{"object_name", proto.ColumnType_STRING},
+		{"ip_address", proto.ColumnType_IPADDR},
+		{"details", proto.ColumnType_JSON},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListAuditLogsMultiPage(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	respPage0Bytes := []byte(`{
+		"logs": [
+			{"action": "UPDATE", "actorEmail": "alice@example.com", "objectType": "CATALOG", "objectIdentifier": "service1", "timestamp": "2025-01-01T00:00:00Z", "changes": {"owners": ["team-a"]}},
+			{"action": "ARCHIVE", "actorApiKeyIdentifier": "ci-key", "objectType": "CATALOG", "objectIdentifier": "service2"}
+		],
+		"page": 0,
+		"totalPages": 2,
+		"total": 3
+	}`)
+	respPage1Bytes := prepareAuditLogResponse(t, []CortexAuditLog{
+		{Action: "DELETE", ObjectIdentifier: "service3"},
+	}, 1, 2, 3)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/audit-logs", "endTime=2025-02-01T00%3A00%3A00Z&page=0&pageSize=1000&startTime=2025-01-01T00%3A00%3A00Z"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, respPage0Bytes, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/audit-logs", "endTime=2025-02-01T00%3A00%3A00Z&page=1&pageSize=1000&startTime=2025-01-01T00%3A00%3A00Z"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, respPage1Bytes, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexAuditLog](100)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	err := listAuditLogs(ctx, client, writer, TimeRange{Start: &start, End: &end})
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(HaveLen(3))
+	g.Expect(writer.Items[0].Actor()).To(Equal("alice@example.com"))
+	g.Expect(writer.Items[0].Details).To(Equal(map[string]interface{}{"changes": map[string]interface{}{"owners": []interface{}{"team-a"}}}))
+	g.Expect(writer.Items[1].Actor()).To(Equal("ci-key"))
+	g.Expect(writer.Items[2].ObjectIdentifier).To(Equal("service3"))
+}
+
+func TestListAuditLogsError(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/audit-logs"),
+			gh.RespondWith(http.StatusInternalServerError, "{\"details\": \"fake error on page 0\"}", nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexAuditLog](100)
+
+	err := listAuditLogs(ctx, client, writer, TimeRange{})
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(Equal("error from cortex API 500 Internal Server Error: {\"details\": \"fake error on page 0\"}"))
+}
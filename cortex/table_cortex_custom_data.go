@@ -0,0 +1,144 @@
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type CortexCustomData struct {
+	ID          int         `yaml:"id"`
+	Key         string      `yaml:"key"`
+	Value       interface{} `yaml:"value"`
+	Description string      `yaml:"description"`
+	Source      string      `yaml:"source"`
+	DateUpdated string      `yaml:"dateUpdated"`
+
+	// Not in the API response, but used to enrich the data
+	EntityTag string `yaml:"-"`
+}
+
+func tableCortexCustomData() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_custom_data",
+		Description: "Cortex entity custom data api.",
+		List: &plugin.ListConfig{
+			Hydrate: listCustomDataHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "entity_tag", Require: plugin.Optional},
+			},
+		},
+		Get: &plugin.GetConfig{
+			Hydrate:    getCustomDataHydrator,
+			KeyColumns: plugin.AllColumns([]string{"entity_tag", "key"}),
+		},
+		Columns: []*plugin.Column{
+			{Name: "entity_tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity."},
+			{Name: "key", Type: proto.ColumnType_STRING, Description: "Custom data key."},
+			{Name: "value", Type: proto.ColumnType_JSON, Description: "Raw custom data value.", Transform: transform.FromField("Value")},
+			{Name: "description", Type: proto.ColumnType_STRING, Description: "Description."},
+			{Name: "source", Type: proto.ColumnType_STRING, Description: "Where the custom data came from, e.g. API or YAML."},
+			{Name: "last_updated", Type: proto.ColumnType_TIMESTAMP, Description: "Last updated time.", Transform: transform.FromField("DateUpdated")},
+		},
+	}
+}
+
+func listCustomDataHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+
+	tags, err := getEntityTags(ctx, d, client, "entity_tag")
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("listCustomDataHydrator", "tags", len(tags))
+	return nil, listCustomData(ctx, client, &hydratorWriter, tags)
+}
+
+func listCustomData(ctx context.Context, client *req.Client, writer HydratorWriter, tags []string) error {
+	logger := plugin.Logger(ctx)
+
+	for _, tag := range tags {
+		logger.Debug("listCustomData", "tag", tag)
+		resp := client.
+			Get("/api/v1/catalog/{tag}/custom-data").
+			SetPathParam("tag", tag).
+			Do(ctx)
+
+		// Check for HTTP errors
+		if resp.IsErrorState() {
+			logger.Error("listCustomData", "tag", tag, "Status", resp.Status, "Body", resp.String())
+			return fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+		}
+
+		// Unmarshal the response and check for unmarshal errors
+		var response []CortexCustomData
+		err := resp.Into(&response)
+		if err != nil {
+			logger.Error("listCustomData", "tag", tag, "Error", err)
+			return err
+		}
+
+		for _, result := range response {
+			// enrich the data
+			result.EntityTag = tag
+			// send the item to steampipe
+			writer.StreamListItem(ctx, result)
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if writer.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func getCustomDataHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	tag := d.EqualsQuals["entity_tag"].GetStringValue()
+	key := d.EqualsQuals["key"].GetStringValue()
+	logger.Info("getCustomDataHydrator", "tag", tag, "key", key)
+	return getCustomData(ctx, client, tag, key)
+}
+
+// getCustomData returns a single custom data key of an entity, or nil if it does not exist.
+func getCustomData(ctx context.Context, client *req.Client, tag string, key string) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+
+	resp := client.
+		Get("/api/v1/catalog/{tag}/custom-data/{key}").
+		SetPathParam("tag", tag).
+		SetPathParam("key", key).
+		Do(ctx)
+
+	// A missing entity or key is not an error, there is just no row
+	if resp.GetStatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	// Check for HTTP errors
+	if resp.IsErrorState() {
+		logger.Error("getCustomData", "tag", tag, "key", key, "Status", resp.Status, "Body", resp.String())
+		return nil, fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+	}
+
+	// Unmarshal the response and check for unmarshal errors
+	var response CortexCustomData
+	err := resp.Into(&response)
+	if err != nil {
+		logger.Error("getCustomData", "tag", tag, "key", key, "Error", err)
+		return nil, err
+	}
+	response.EntityTag = tag
+	return response, nil
+}
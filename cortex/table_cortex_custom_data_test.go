@@ -0,0 +1,141 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func TestTableCortexCustomData(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexCustomData()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_custom_data"))
+	g.Expect(table.Description).To(Equal("Cortex entity custom data api."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(1))
+	g.Expect(table.List.KeyColumns[0].Name).To(Equal("entity_tag"))
+	g.Expect(table.List.KeyColumns[0].Require).To(Equal(plugin.Optional))
+
+	// Check get configuration.
+	g.Expect(table.Get).ToNot(BeNil())
+	g.Expect(table.Get.Hydrate).ToNot(BeNil())
+	g.Expect(table.Get.KeyColumns).To(HaveLen(2))
+	g.Expect(table.Get.KeyColumns[0].Name).To(Equal("entity_tag"))
+	g.Expect(table.Get.KeyColumns[0].Require).To(Equal(plugin.Required))
+	g.Expect(table.Get.KeyColumns[1].Name).To(Equal("key"))
+	g.Expect(table.Get.KeyColumns[1].Require).To(Equal(plugin.Required))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"entity_tag", proto.ColumnType_STRING},
+		{"key", proto.ColumnType_STRING},
+		{"value", proto.ColumnType_JSON},
+		{"description", proto.ColumnType_STRING},
+		{"source", proto.ColumnType_STRING},
+		{"last_updated", proto.ColumnType_TIMESTAMP},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListCustomData(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/custom-data"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, `[
+				{"id": 1, "key": "coverage", "value": 87.5, "source": "API", "dateUpdated": "2025-01-01T00:00:00Z"},
+				{"id": 2, "key": "owners", "value": {"primary": "team-a"}, "source": "YAML"}
+			]`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service2/custom-data"),
+			gh.RespondWith(http.StatusOK, `[]`, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexCustomData](100)
+
+	err := listCustomData(ctx, client, writer, []string{"service1", "service2"})
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(HaveLen(2))
+	g.Expect(writer.Items[0].EntityTag).To(Equal("service1"))
+	g.Expect(writer.Items[0].Value).To(Equal(87.5))
+	g.Expect(writer.Items[0].Source).To(Equal("API"))
+	g.Expect(writer.Items[1].Value).To(Equal(map[string]interface{}{"primary": "team-a"}))
+}
+
+func TestListCustomDataError(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/custom-data"),
+			gh.RespondWith(http.StatusInternalServerError, "{\"details\": \"fake error\"}", nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexCustomData](100)
+
+	err := listCustomData(ctx, client, writer, []string{"service1"})
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(Equal("error from cortex API 500 Internal Server Error: {\"details\": \"fake error\"}"))
+}
+
+func TestGetCustomData(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/custom-data/coverage"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, `{"id": 1, "key": "coverage", "value": 87.5, "description": "Line coverage", "source": "API"}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/custom-data/missing"),
+			gh.RespondWith(http.StatusNotFound, "{\"details\": \"not found\"}", nil),
+		),
+	)
+	defer server.Close()
+
+	item, err := getCustomData(ctx, client, "service1", "coverage")
+	g.Expect(err).To(BeNil())
+	g.Expect(item).To(Equal(CortexCustomData{
+		ID:          1,
+		Key:         "coverage",
+		Value:       87.5,
+		Description: "Line coverage",
+		Source:      "API",
+		EntityTag:   "service1",
+	}))
+
+	item, err = getCustomData(ctx, client, "service1", "missing")
+	g.Expect(err).To(BeNil())
+	g.Expect(item).To(BeNil())
+}
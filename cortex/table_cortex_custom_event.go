@@ -0,0 +1,134 @@
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+type CortexCustomEventsResponse struct {
+	Events     []CortexCustomEvent `yaml:"events"`
+	Page       int                 `yaml:"page"`
+	TotalPages int                 `yaml:"totalPages"`
+	Total      int                 `yaml:"total"`
+}
+
+type CortexCustomEvent struct {
+	UUID        string                 `yaml:"uuid"`
+	Type        string                 `yaml:"type"`
+	Title       string                 `yaml:"title"`
+	Description string                 `yaml:"description"`
+	Timestamp   string                 `yaml:"timestamp"`
+	Url         string                 `yaml:"url"`
+	CustomData  map[string]interface{} `yaml:"customData"`
+
+	// Not in the API response, but used to enrich the data
+	EntityTag string `yaml:"-"`
+}
+
+func tableCortexCustomEvent() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_custom_event",
+		Description: "Cortex entity custom events api.",
+		List: &plugin.ListConfig{
+			Hydrate: listCustomEventsHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "entity_tag", Require: plugin.Optional},
+				{Name: "type", Require: plugin.Optional},
+				{Name: "timestamp", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "entity_tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity."},
+			{Name: "uuid", Type: proto.ColumnType_STRING, Description: "Unique identifier of the event."},
+			{Name: "type", Type: proto.ColumnType_STRING, Description: "Event type."},
+			{Name: "title", Type: proto.ColumnType_STRING, Description: "Title."},
+			{Name: "description", Type: proto.ColumnType_STRING, Description: "Description."},
+			{Name: "timestamp", Type: proto.ColumnType_TIMESTAMP, Description: "Time of the event."},
+			{Name: "url", Type: proto.ColumnType_STRING, Description: "Link to more information about the event."},
+			{Name: "custom_data", Type: proto.ColumnType_JSON, Description: "Raw custom data"},
+		},
+	}
+}
+
+func listCustomEventsHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+
+	tags, err := getEntityTags(ctx, d, client, "entity_tag")
+	if err != nil {
+		return nil, err
+	}
+
+	eventType := ""
+	if d.EqualsQuals["type"] != nil {
+		eventType = d.EqualsQuals["type"].GetStringValue()
+	}
+
+	var timeRange TimeRange
+	if d.Quals["timestamp"] != nil {
+		timeRange = timeRangeFromQuals(d.Quals["timestamp"].Quals)
+	}
+
+	logger.Info("listCustomEventsHydrator", "tags", len(tags), "type", eventType, "timeRange", timeRange)
+	return nil, listCustomEvents(ctx, client, &hydratorWriter, tags, eventType, timeRange)
+}
+
+func listCustomEvents(ctx context.Context, client *req.Client, writer HydratorWriter, tags []string, eventType string, timeRange TimeRange) error {
+	logger := plugin.Logger(ctx)
+
+	for _, tag := range tags {
+		var page int = 0
+		for {
+			var response CortexCustomEventsResponse
+			logger.Debug("listCustomEvents", "tag", tag, "page", page)
+			request := client.
+				Get("/api/v1/catalog/{tag}/custom-events").
+				SetPathParam("tag", tag).
+				// Filters
+				SetQueryParams(timeRange.QueryParams("startTime", "endTime")).
+				// Pagination
+				SetQueryParam("pageSize", "1000").
+				SetQueryParam("page", strconv.Itoa(page))
+			if eventType != "" {
+				request.SetQueryParam("type", eventType)
+			}
+			resp := request.Do(ctx)
+
+			// Check for HTTP errors
+			if resp.IsErrorState() {
+				logger.Error("listCustomEvents", "tag", tag, "Status", resp.Status, "Body", resp.String())
+				return fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+			}
+
+			// Unmarshal the response and check for unmarshal errors
+			err := resp.Into(&response)
+			if err != nil {
+				logger.Error("listCustomEvents", "tag", tag, "page", page, "Error", err)
+				return err
+			}
+
+			for _, result := range response.Events {
+				// enrich the data
+				result.EntityTag = tag
+				// send the item to steampipe
+				writer.StreamListItem(ctx, result)
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if writer.RowsRemaining(ctx) == 0 {
+					return nil
+				}
+			}
+			page++
+			if page >= response.TotalPages {
+				break
+			}
+		}
+	}
+	return nil
+}
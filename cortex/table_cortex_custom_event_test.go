@@ -0,0 +1,146 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"gopkg.in/yaml.v3"
+)
+
+func prepareCustomEventsResponse(t *testing.T, events []CortexCustomEvent, page, totalPages, total int) []byte {
+	t.Helper()
+	response := CortexCustomEventsResponse{
+		Events:     events,
+		Page:       page,
+		TotalPages: totalPages,
+		Total:      total,
+	}
+	responseBytes, err := yaml.Marshal(response)
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	return responseBytes
+}
+
+func TestTableCortexCustomEvent(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexCustomEvent()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_custom_event"))
+	g.Expect(table.Description).To(Equal("Cortex entity custom events api."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(3))
+	g.Expect(table.List.KeyColumns[0].Name).To(Equal("entity_tag"))
+	g.Expect(table.List.KeyColumns[0].Require).To(Equal(plugin.Optional))
+	g.Expect(table.List.KeyColumns[1].Name).To(Equal("type"))
+	g.Expect(table.List.KeyColumns[1].Require).To(Equal(plugin.Optional))
+	g.Expect(table.List.KeyColumns[2].Name).To(Equal("timestamp"))
+	g.Expect(table.List.KeyColumns[2].Require).To(Equal(plugin.Optional))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"entity_tag", proto.ColumnType_STRING},
+		{"uuid", proto.ColumnType_STRING},
+		{"type", proto.ColumnType_STRING},
+		{"title", proto.ColumnType_STRING},
+		{"description", proto.ColumnType_STRING},
+		{"timestamp", proto.ColumnType_TIMESTAMP},
+		{"url", proto.ColumnType_STRING},
+		{"custom_data", proto.ColumnType_JSON},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListCustomEventsWithFilters(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	responseBytes := prepareCustomEventsResponse(t, []CortexCustomEvent{
+		{UUID: "1", Type: "migration", Title: "Moved to k8s", CustomData: map[string]interface{}{"ticket": "OPS-1"}},
+	}, 0, 1, 1)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/custom-events", "endTime=2025-02-01T00%3A00%3A00Z&page=0&pageSize=1000&startTime=2025-01-01T00%3A00%3A00Z&type=migration"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, responseBytes, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexCustomEvent](100)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	err := listCustomEvents(ctx, client, writer, []string{"service1"}, "migration", TimeRange{Start: &start, End: &end})
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(HaveLen(1))
+	g.Expect(writer.Items[0].EntityTag).To(Equal("service1"))
+	g.Expect(writer.Items[0].Title).To(Equal("Moved to k8s"))
+	g.Expect(writer.Items[0].CustomData).To(HaveKeyWithValue("ticket", "OPS-1"))
+}
+
+func TestListCustomEventsFanOut(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/custom-events", "page=0&pageSize=1000"),
+			gh.RespondWith(http.StatusOK, prepareCustomEventsResponse(t, []CortexCustomEvent{{UUID: "1"}}, 0, 1, 1), nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service2/custom-events", "page=0&pageSize=1000"),
+			gh.RespondWith(http.StatusOK, prepareCustomEventsResponse(t, []CortexCustomEvent{{UUID: "2"}}, 0, 1, 1), nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexCustomEvent](100)
+
+	err := listCustomEvents(ctx, client, writer, []string{"service1", "service2"}, "", TimeRange{})
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(HaveLen(2))
+	g.Expect(writer.Items[0].EntityTag).To(Equal("service1"))
+	g.Expect(writer.Items[1].EntityTag).To(Equal("service2"))
+}
+
+func TestListCustomEventsError(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/custom-events"),
+			gh.RespondWith(http.StatusInternalServerError, "{\"details\": \"fake error on page 0\"}", nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexCustomEvent](100)
+
+	err := listCustomEvents(ctx, client, writer, []string{"service1"}, "", TimeRange{})
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(Equal("error from cortex API 500 Internal Server Error: {\"details\": \"fake error on page 0\"}"))
+}
@@ -0,0 +1,209 @@
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+const (
+	DependencySourceDescriptor = "descriptor"
+	DependencySourceDiscovered = "discovered"
+)
+
+// Response elements for the /catalog/{tag}/dependencies endpoint
+type CortexDependenciesResponse struct {
+	Tag      string                 `yaml:"tag"`
+	Incoming []CortexDependencyEdge `yaml:"incoming"`
+	Outgoing []CortexDependencyEdge `yaml:"outgoing"`
+}
+
+type CortexDependencyEdge struct {
+	CallerTag   string                 `yaml:"callerTag"`
+	CalleeTag   string                 `yaml:"calleeTag"`
+	Method      string                 `yaml:"method"`
+	Path        string                 `yaml:"path"`
+	Description string                 `yaml:"description"`
+	Metadata    map[string]interface{} `yaml:"metadata"`
+
+	// Not in the API response, but used to enrich the data
+	Source string `yaml:"-"`
+}
+
+// key identifies an edge by caller, callee, method and path.
+func (e CortexDependencyEdge) key() string {
+	return strings.Join([]string{e.CallerTag, e.CalleeTag, strings.ToUpper(e.Method), e.Path}, "|")
+}
+
+func tableCortexDependency() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_dependency",
+		Description: "Cortex entity dependencies api.",
+		List: &plugin.ListConfig{
+			Hydrate: listDependenciesHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "caller_tag", Require: plugin.Optional},
+				{Name: "callee_tag", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "caller_tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity making the call."},
+			{Name: "callee_tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity being called."},
+			{Name: "method", Type: proto.ColumnType_STRING, Description: "HTTP method of the dependency, if any."},
+			{Name: "path", Type: proto.ColumnType_STRING, Description: "Path of the dependency, if any."},
+			{Name: "description", Type: proto.ColumnType_STRING, Description: "Description."},
+			{Name: "metadata", Type: proto.ColumnType_JSON, Description: "Raw dependency metadata"},
+			{Name: "source", Type: proto.ColumnType_STRING, Description: "Where the edge came from: descriptor or discovered."},
+		},
+	}
+}
+
+func listDependenciesHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+
+	// Query the smallest part of the graph we can: outgoing edges of the
+	// caller, incoming edges of the callee, or outgoing edges of every entity.
+	// The edges of a caller can only be declared in its own descriptor.
+	incoming := false
+	var declared map[string]bool
+	var err error
+	callers := qualStrings(d.EqualsQuals["caller_tag"])
+	tags := qualStrings(d.EqualsQuals["callee_tag"])
+	if len(callers) > 0 {
+		tags = callers
+		declared, err = getDeclaredDependencies(ctx, client, tags)
+	} else if len(tags) > 0 {
+		incoming = true
+		declared, err = listDeclaredDependencies(ctx, client)
+	} else {
+		tags, err = listEntityTags(ctx, client, "")
+		if err != nil {
+			return nil, err
+		}
+		declared, err = listDeclaredDependencies(ctx, client)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("listDependenciesHydrator", "tags", len(tags), "incoming", incoming)
+	return nil, listDependencies(ctx, client, &hydratorWriter, tags, incoming, declared)
+}
+
+// listDeclaredDependencies returns the keys of all edges declared in the
+// x-cortex-dependency block of the descriptors.
+func listDeclaredDependencies(ctx context.Context, client *req.Client) (map[string]bool, error) {
+	descriptors := SliceWriter[CortexInfo]{Limit: math.MaxInt64}
+	if err := listDescriptors(ctx, client, &descriptors, false); err != nil {
+		return nil, err
+	}
+	return declaredDependencies(descriptors.Items), nil
+}
+
+// getDeclaredDependencies returns the keys of the edges declared in the
+// descriptors of the given entities. Entities without a descriptor have none.
+func getDeclaredDependencies(ctx context.Context, client *req.Client, tags []string) (map[string]bool, error) {
+	var descriptors []CortexInfo
+	for _, tag := range tags {
+		descriptor, err := getDescriptor(ctx, client, tag, false)
+		if err != nil {
+			return nil, err
+		}
+		if descriptor != nil {
+			descriptors = append(descriptors, descriptor.Info)
+		}
+	}
+	return declaredDependencies(descriptors), nil
+}
+
+// declaredDependencies returns the keys of the edges in the x-cortex-dependency
+// block of each descriptor.
+func declaredDependencies(descriptors []CortexInfo) map[string]bool {
+	declared := make(map[string]bool)
+	for _, descriptor := range descriptors {
+		for _, dependency := range descriptor.Dependency.Cortex {
+			edge := CortexDependencyEdge{
+				CallerTag: descriptor.Tag,
+				CalleeTag: dependency.Tag,
+				Method:    dependency.Method,
+				Path:      dependency.Path,
+			}
+			declared[edge.key()] = true
+		}
+	}
+	return declared
+}
+
+func listDependencies(ctx context.Context, client *req.Client, writer HydratorWriter, tags []string, incoming bool, declared map[string]bool) error {
+	logger := plugin.Logger(ctx)
+
+	for _, tag := range tags {
+		logger.Debug("listDependencies", "tag", tag, "incoming", incoming)
+		resp := client.
+			Get("/api/v1/catalog/{tag}/dependencies").
+			SetPathParam("tag", tag).
+			// Options
+			SetQueryParam("includeOutgoing", fmt.Sprint(!incoming)).
+			SetQueryParam("includeIncoming", fmt.Sprint(incoming)).
+			Do(ctx)
+
+		// Check for HTTP errors
+		if resp.IsErrorState() {
+			logger.Error("listDependencies", "tag", tag, "Status", resp.Status, "Body", resp.String())
+			return fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+		}
+
+		// Unmarshal the response and check for unmarshal errors
+		var response CortexDependenciesResponse
+		err := resp.Into(&response)
+		if err != nil {
+			logger.Error("listDependencies", "tag", tag, "Error", err)
+			return err
+		}
+
+		edges := response.Outgoing
+		if incoming {
+			edges = response.Incoming
+		}
+		for _, result := range edges {
+			// enrich the data
+			result.Source = DependencySourceDiscovered
+			if declared[result.key()] {
+				result.Source = DependencySourceDescriptor
+			}
+			// send the item to steampipe
+			writer.StreamListItem(ctx, result)
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if writer.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// listAllDependencyEdges returns the outgoing edges of every entity in the
+// catalog, which together make up the whole dependency graph.
+func listAllDependencyEdges(ctx context.Context, client *req.Client) ([]CortexDependencyEdge, error) {
+	tags, err := listEntityTags(ctx, client, "")
+	if err != nil {
+		return nil, err
+	}
+	declared, err := listDeclaredDependencies(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	edges := SliceWriter[CortexDependencyEdge]{Limit: math.MaxInt64}
+	if err := listDependencies(ctx, client, &edges, tags, false, declared); err != nil {
+		return nil, err
+	}
+	return edges.Items, nil
+}
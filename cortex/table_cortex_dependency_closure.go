@@ -0,0 +1,82 @@
+package cortex
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func tableCortexDependencyClosure() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_dependency_closure",
+		Description: "Transitive upstream and downstream dependencies of each entity.",
+		List: &plugin.ListConfig{
+			Hydrate: listDependencyClosureHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "tag", Require: plugin.Optional},
+				{Name: "direction", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity."},
+			{Name: "related_tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the directly or indirectly related entity."},
+			{Name: "direction", Type: proto.ColumnType_STRING, Description: "upstream if the entity depends on the related entity, downstream if the related entity depends on it."},
+			{Name: "hops", Type: proto.ColumnType_INT, Description: "Number of edges on the shortest path between the entities."},
+			{Name: "path", Type: proto.ColumnType_JSON, Description: "An example shortest path of tags in call order, from caller to callee."},
+		},
+	}
+}
+
+func listDependencyClosureHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+
+	// Unknown directions have no rows, so there is nothing to fetch
+	directions := []string{DependencyDirectionUpstream, DependencyDirectionDownstream}
+	if d.EqualsQuals["direction"] != nil {
+		directions = nil
+		for _, direction := range qualStrings(d.EqualsQuals["direction"]) {
+			if !isDependencyDirection(direction) {
+				logger.Warn("listDependencyClosureHydrator", "unknown direction", direction)
+				continue
+			}
+			directions = append(directions, direction)
+		}
+		if len(directions) == 0 {
+			return nil, nil
+		}
+	}
+
+	edges, err := listAllDependencyEdges(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	graph := NewDependencyGraph(edges)
+
+	tags := graph.Tags
+	if d.EqualsQuals["tag"] != nil {
+		tags = qualStrings(d.EqualsQuals["tag"])
+	}
+
+	logger.Info("listDependencyClosureHydrator", "edges", len(edges), "tags", len(tags), "directions", directions)
+	listDependencyClosure(ctx, &hydratorWriter, graph, tags, directions)
+	return nil, nil
+}
+
+func listDependencyClosure(ctx context.Context, writer HydratorWriter, graph *DependencyGraph, tags []string, directions []string) {
+	for _, tag := range tags {
+		for _, direction := range directions {
+			for _, row := range graph.Closure(tag, direction) {
+				// send the item to steampipe
+				writer.StreamListItem(ctx, row)
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if writer.RowsRemaining(ctx) == 0 {
+					return
+				}
+			}
+		}
+	}
+}
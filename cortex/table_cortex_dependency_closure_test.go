@@ -0,0 +1,79 @@
+package cortex
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func TestTableCortexDependencyClosure(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexDependencyClosure()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_dependency_closure"))
+	g.Expect(table.Description).To(Equal("Transitive upstream and downstream dependencies of each entity."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(2))
+	g.Expect(table.List.KeyColumns[0].Name).To(Equal("tag"))
+	g.Expect(table.List.KeyColumns[0].Require).To(Equal(plugin.Optional))
+	g.Expect(table.List.KeyColumns[1].Name).To(Equal("direction"))
+	g.Expect(table.List.KeyColumns[1].Require).To(Equal(plugin.Optional))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"tag", proto.ColumnType_STRING},
+		{"related_tag", proto.ColumnType_STRING},
+		{"direction", proto.ColumnType_STRING},
+		{"hops", proto.ColumnType_INT},
+		{"path", proto.ColumnType_JSON},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListDependencyClosure(t *testing.T) {
+	g := NewWithT(t)
+	graph := NewDependencyGraph(testDependencyEdges())
+
+	writer := NewSliceWriter[CortexDependencyClosureRow](100)
+	listDependencyClosure(context.Background(), writer, graph, []string{"c"}, []string{DependencyDirectionUpstream, DependencyDirectionDownstream})
+
+	g.Expect(writer.Items).To(HaveLen(4))
+	g.Expect(writer.Items[0].RelatedTag).To(Equal("b"))
+	g.Expect(writer.Items[0].Direction).To(Equal(DependencyDirectionUpstream))
+	g.Expect(writer.Items[1].RelatedTag).To(Equal("d"))
+	g.Expect(writer.Items[2].RelatedTag).To(Equal("a"))
+	g.Expect(writer.Items[2].Direction).To(Equal(DependencyDirectionDownstream))
+	g.Expect(writer.Items[3].RelatedTag).To(Equal("b"))
+
+	// An unknown direction has no rows
+	writer = NewSliceWriter[CortexDependencyClosureRow](100)
+	listDependencyClosure(context.Background(), writer, graph, []string{"c"}, []string{"sideways"})
+	g.Expect(writer.Items).To(BeEmpty())
+}
+
+func TestListDependencyClosureLimit(t *testing.T) {
+	g := NewWithT(t)
+	graph := NewDependencyGraph(testDependencyEdges())
+
+	writer := NewSliceWriter[CortexDependencyClosureRow](2)
+	listDependencyClosure(context.Background(), writer, graph, graph.Tags, []string{DependencyDirectionUpstream})
+
+	g.Expect(writer.Items).To(HaveLen(2))
+}
@@ -0,0 +1,51 @@
+package cortex
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableCortexDependencyCycle() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_dependency_cycle",
+		Description: "Dependency cycles as strongly connected components of the dependency graph.",
+		List: &plugin.ListConfig{
+			Hydrate: listDependencyCyclesHydrator,
+		},
+		Columns: []*plugin.Column{
+			{Name: "cycle_id", Type: proto.ColumnType_INT, Description: "Identifier of the cycle, only stable while the graph is unchanged.", Transform: transform.FromField("CycleID")},
+			{Name: "size", Type: proto.ColumnType_INT, Description: "Number of entities in the cycle."},
+			{Name: "tags", Type: proto.ColumnType_JSON, Description: "Sorted x-cortex-tags of the entities in the cycle."},
+		},
+	}
+}
+
+func listDependencyCyclesHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+
+	edges, err := listAllDependencyEdges(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("listDependencyCyclesHydrator", "edges", len(edges))
+	listDependencyCycles(ctx, &hydratorWriter, NewDependencyGraph(edges))
+	return nil, nil
+}
+
+func listDependencyCycles(ctx context.Context, writer HydratorWriter, graph *DependencyGraph) {
+	for _, row := range graph.Cycles() {
+		// send the item to steampipe
+		writer.StreamListItem(ctx, row)
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if writer.RowsRemaining(ctx) == 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package cortex
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestTableCortexDependencyCycle(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexDependencyCycle()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_dependency_cycle"))
+	g.Expect(table.Description).To(Equal("Dependency cycles as strongly connected components of the dependency graph."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"cycle_id", proto.ColumnType_INT},
+		{"size", proto.ColumnType_INT},
+		{"tags", proto.ColumnType_JSON},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListDependencyCycles(t *testing.T) {
+	g := NewWithT(t)
+	graph := NewDependencyGraph(testDependencyEdges())
+
+	writer := NewSliceWriter[CortexDependencyCycleRow](100)
+	listDependencyCycles(context.Background(), writer, graph)
+
+	g.Expect(writer.Items).To(HaveLen(2))
+	g.Expect(writer.Items[0].Tags).To(Equal([]string{"b", "c"}))
+	g.Expect(writer.Items[1].Tags).To(Equal([]string{"e"}))
+}
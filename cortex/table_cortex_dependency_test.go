@@ -0,0 +1,211 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"gopkg.in/yaml.v3"
+)
+
+func prepareDependenciesResponse(t *testing.T, tag string, incoming, outgoing []CortexDependencyEdge) []byte {
+	t.Helper()
+	response := CortexDependenciesResponse{
+		Tag:      tag,
+		Incoming: incoming,
+		Outgoing: outgoing,
+	}
+	responseBytes, err := yaml.Marshal(response)
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	return responseBytes
+}
+
+func TestTableCortexDependency(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexDependency()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_dependency"))
+	g.Expect(table.Description).To(Equal("Cortex entity dependencies api."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(2))
+	g.Expect(table.List.KeyColumns[0].Name).To(Equal("caller_tag"))
+	g.Expect(table.List.KeyColumns[0].Require).To(Equal(plugin.Optional))
+	g.Expect(table.List.KeyColumns[1].Name).To(Equal("callee_tag"))
+	g.Expect(table.List.KeyColumns[1].Require).To(Equal(plugin.Optional))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"caller_tag", proto.ColumnType_STRING},
+		{"callee_tag", proto.ColumnType_STRING},
+		{"method", proto.ColumnType_STRING},
+		{"path", proto.ColumnType_STRING},
+		{"description", proto.ColumnType_STRING},
+		{"metadata", proto.ColumnType_JSON},
+		{"source", proto.ColumnType_STRING},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListDeclaredDependencies(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	responseBytes := prepareDescriptorResponse(t, []Cortex{
+		{Info: CortexInfo{Tag: "service1", Dependency: CortexDependency{Cortex: []CortexDependencyCortex{
+			{Tag: "service2", Method: "get", Path: "/users"},
+			{Tag: "service3"},
+		}}}},
+		{Info: CortexInfo{Tag: "service2"}},
+	}, 0, 1, 2)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/descriptors"),
+			gh.RespondWith(http.StatusOK, responseBytes, nil),
+		),
+	)
+	defer server.Close()
+
+	declared, err := listDeclaredDependencies(ctx, client)
+	g.Expect(err).To(BeNil())
+	g.Expect(declared).To(Equal(map[string]bool{
+		"service1|service2|GET|/users": true,
+		"service1|service3||":          true,
+	}))
+}
+
+func TestGetDeclaredDependencies(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	// Only the descriptor of the caller is fetched
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/openapi", "yaml=false"),
+			gh.RespondWith(http.StatusOK, `{"openapi": "3.0.1", "info": {"x-cortex-tag": "service1", "x-cortex-dependency": {"cortex": [{"tag": "service2", "method": "get", "path": "/users"}]}}}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/missing/openapi", "yaml=false"),
+			gh.RespondWith(http.StatusNotFound, `{"message": "Not found"}`, nil),
+		),
+	)
+	defer server.Close()
+
+	declared, err := getDeclaredDependencies(ctx, client, []string{"service1", "missing"})
+	g.Expect(err).To(BeNil())
+	g.Expect(declared).To(Equal(map[string]bool{"service1|service2|GET|/users": true}))
+}
+
+func TestQualStrings(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(qualStrings(nil)).To(BeNil())
+	g.Expect(qualStrings(&proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: "service1"}})).To(Equal([]string{"service1"}))
+
+	// A list from `in (...)` has each of its strings
+	list := &proto.QualValue{Value: &proto.QualValue_ListValue{ListValue: &proto.QualValueList{Values: []*proto.QualValue{
+		{Value: &proto.QualValue_StringValue{StringValue: "service1"}},
+		{Value: &proto.QualValue_StringValue{StringValue: "service2"}},
+	}}}}
+	g.Expect(qualStrings(list)).To(Equal([]string{"service1", "service2"}))
+
+	// Anything else has no strings, so the hydrators scan everything
+	g.Expect(qualStrings(&proto.QualValue{Value: &proto.QualValue_Int64Value{Int64Value: 1}})).To(BeNil())
+}
+
+func TestListDependenciesOutgoing(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/dependencies", "includeIncoming=false&includeOutgoing=true"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, prepareDependenciesResponse(t, "service1", nil, []CortexDependencyEdge{
+				{CallerTag: "service1", CalleeTag: "service2", Method: "GET", Path: "/users"},
+				{CallerTag: "service1", CalleeTag: "database1", Metadata: map[string]interface{}{"discoveredBy": "datadog"}},
+			}), nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service2/dependencies"),
+			gh.RespondWith(http.StatusOK, prepareDependenciesResponse(t, "service2", nil, nil), nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexDependencyEdge](100)
+
+	declared := map[string]bool{"service1|service2|GET|/users": true}
+	err := listDependencies(ctx, client, writer, []string{"service1", "service2"}, false, declared)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(HaveLen(2))
+	g.Expect(writer.Items[0].CalleeTag).To(Equal("service2"))
+	g.Expect(writer.Items[0].Source).To(Equal(DependencySourceDescriptor))
+	g.Expect(writer.Items[1].CalleeTag).To(Equal("database1"))
+	g.Expect(writer.Items[1].Source).To(Equal(DependencySourceDiscovered))
+	g.Expect(writer.Items[1].Metadata).To(HaveKeyWithValue("discoveredBy", "datadog"))
+}
+
+func TestListDependenciesIncoming(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service2/dependencies", "includeIncoming=true&includeOutgoing=false"),
+			gh.RespondWith(http.StatusOK, prepareDependenciesResponse(t, "service2", []CortexDependencyEdge{
+				{CallerTag: "service1", CalleeTag: "service2"},
+			}, []CortexDependencyEdge{
+				{CallerTag: "service2", CalleeTag: "service3"},
+			}), nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexDependencyEdge](100)
+
+	err := listDependencies(ctx, client, writer, []string{"service2"}, true, map[string]bool{})
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(HaveLen(1))
+	g.Expect(writer.Items[0].CallerTag).To(Equal("service1"))
+}
+
+func TestListDependenciesError(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/dependencies"),
+			gh.RespondWith(http.StatusInternalServerError, "{\"details\": \"fake error\"}", nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexDependencyEdge](100)
+
+	err := listDependencies(ctx, client, writer, []string{"service1"}, false, map[string]bool{})
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(Equal("error from cortex API 500 Internal Server Error: {\"details\": \"fake error\"}"))
+}
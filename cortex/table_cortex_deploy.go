@@ -0,0 +1,135 @@
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type CortexDeploysResponse struct {
+	Deployments []CortexDeploy `yaml:"deployments"`
+	Page        int            `yaml:"page"`
+	TotalPages  int            `yaml:"totalPages"`
+	Total       int            `yaml:"total"`
+}
+
+type CortexDeploy struct {
+	UUID        string                 `yaml:"uuid"`
+	Title       string                 `yaml:"title"`
+	Type        string                 `yaml:"type"`
+	Sha         string                 `yaml:"sha"`
+	Environment string                 `yaml:"environment"`
+	Timestamp   string                 `yaml:"timestamp"`
+	Deployer    CortexDeployer         `yaml:"deployer"`
+	CustomData  map[string]interface{} `yaml:"customData"`
+
+	// Not in the API response, but used to enrich the data
+	EntityTag string `yaml:"-"`
+}
+
+type CortexDeployer struct {
+	Name  string `yaml:"name"`
+	Email string `yaml:"email"`
+}
+
+func tableCortexDeploy() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_deploy",
+		Description: "Cortex entity deploys api.",
+		List: &plugin.ListConfig{
+			Hydrate: listDeploysHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "entity_tag", Require: plugin.Optional},
+				{Name: "timestamp", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "entity_tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the deployed entity."},
+			{Name: "uuid", Type: proto.ColumnType_STRING, Description: "Unique identifier of the deploy."},
+			{Name: "timestamp", Type: proto.ColumnType_TIMESTAMP, Description: "Time of the deploy."},
+			{Name: "title", Type: proto.ColumnType_STRING, Description: "Title."},
+			{Name: "type", Type: proto.ColumnType_STRING, Description: "Deploy type: DEPLOY, SCALE, ROLLBACK or RESTART."},
+			{Name: "sha", Type: proto.ColumnType_STRING, Description: "Git commit SHA that was deployed."},
+			{Name: "environment", Type: proto.ColumnType_STRING, Description: "Environment deployed to."},
+			{Name: "deployer_name", Type: proto.ColumnType_STRING, Description: "Name of the deployer.", Transform: transform.FromField("Deployer.Name")},
+			{Name: "deployer_email", Type: proto.ColumnType_STRING, Description: "Email of the deployer.", Transform: transform.FromField("Deployer.Email")},
+			{Name: "custom_data", Type: proto.ColumnType_JSON, Description: "Raw custom data"},
+		},
+	}
+}
+
+func listDeploysHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+
+	tags, err := getEntityTags(ctx, d, client, "entity_tag")
+	if err != nil {
+		return nil, err
+	}
+
+	var timeRange TimeRange
+	if d.Quals["timestamp"] != nil {
+		timeRange = timeRangeFromQuals(d.Quals["timestamp"].Quals)
+	}
+
+	logger.Info("listDeploysHydrator", "tags", len(tags), "timeRange", timeRange)
+	return nil, listDeploys(ctx, client, &hydratorWriter, tags, timeRange)
+}
+
+func listDeploys(ctx context.Context, client *req.Client, writer HydratorWriter, tags []string, timeRange TimeRange) error {
+	logger := plugin.Logger(ctx)
+
+	for _, tag := range tags {
+		var page int = 0
+		for {
+			var response CortexDeploysResponse
+			logger.Debug("listDeploys", "tag", tag, "page", page)
+			resp := client.
+				Get("/api/v1/catalog/{tag}/deploys").
+				SetPathParam("tag", tag).
+				// Pagination
+				SetQueryParam("pageSize", "1000").
+				SetQueryParam("page", strconv.Itoa(page)).
+				Do(ctx)
+
+			// Check for HTTP errors
+			if resp.IsErrorState() {
+				logger.Error("listDeploys", "tag", tag, "Status", resp.Status, "Body", resp.String())
+				return fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+			}
+
+			// Unmarshal the response and check for unmarshal errors
+			err := resp.Into(&response)
+			if err != nil {
+				logger.Error("listDeploys", "tag", tag, "page", page, "Error", err)
+				return err
+			}
+
+			for _, result := range response.Deployments {
+				if !timeRange.Contains(result.Timestamp) {
+					continue
+				}
+				// enrich the data
+				result.EntityTag = tag
+				// send the item to steampipe
+				writer.StreamListItem(ctx, result)
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if writer.RowsRemaining(ctx) == 0 {
+					return nil
+				}
+			}
+			page++
+			if page >= response.TotalPages {
+				break
+			}
+		}
+	}
+	return nil
+}
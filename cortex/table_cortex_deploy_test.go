@@ -0,0 +1,220 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
+)
+
+func prepareDeploysResponse(t *testing.T, deploys []CortexDeploy, page, totalPages, total int) []byte {
+	t.Helper()
+	response := CortexDeploysResponse{
+		Deployments: deploys,
+		Page:        page,
+		TotalPages:  totalPages,
+		Total:       total,
+	}
+	responseBytes, err := yaml.Marshal(response)
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	return responseBytes
+}
+
+func TestTableCortexDeploy(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexDeploy()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_deploy"))
+	g.Expect(table.Description).To(Equal("Cortex entity deploys api."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(2))
+	g.Expect(table.List.KeyColumns[0].Name).To(Equal("entity_tag"))
+	g.Expect(table.List.KeyColumns[0].Require).To(Equal(plugin.Optional))
+	g.Expect(table.List.KeyColumns[1].Name).To(Equal("timestamp"))
+	g.Expect(table.List.KeyColumns[1].Require).To(Equal(plugin.Optional))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"entity_tag", proto.ColumnType_STRING},
+		{"uuid", proto.ColumnType_STRING},
+		{"timestamp", proto.ColumnType_TIMESTAMP},
+		{"title", proto.ColumnType_STRING},
+		{"type", proto.ColumnType_STRING},
+		{"sha", proto.ColumnType_STRING},
+		{"environment", proto.ColumnType_STRING},
+		{"deployer_name", proto.ColumnType_STRING},
+		{"deployer_email", proto.ColumnType_STRING},
+		{"custom_data", proto.ColumnType_JSON},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListDeploysMultiPage(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	respPage0Bytes := prepareDeploysResponse(t, []CortexDeploy{
+		{UUID: "1", Type: "DEPLOY", Timestamp: "2025-01-01T00:00:00Z", Deployer: CortexDeployer{Email: "a@example.com"}},
+		{UUID: "2", Type: "ROLLBACK", Timestamp: "2025-02-01T00:00:00Z"},
+	}, 0, 2, 3)
+	respPage1Bytes := prepareDeploysResponse(t, []CortexDeploy{
+		{UUID: "3", Type: "DEPLOY", Timestamp: "2025-03-01T00:00:00Z"},
+	}, 1, 2, 3)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/deploys", "pageSize=1000&page=0"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, respPage0Bytes, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/deploys", "pageSize=1000&page=1"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, respPage1Bytes, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexDeploy](100)
+
+	err := listDeploys(ctx, client, writer, []string{"service1"}, TimeRange{})
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(HaveLen(3))
+	g.Expect(writer.Items[0].EntityTag).To(Equal("service1"))
+	g.Expect(writer.Items[0].Deployer.Email).To(Equal("a@example.com"))
+	g.Expect(writer.Items[1].Type).To(Equal("ROLLBACK"))
+	g.Expect(writer.Items[2].UUID).To(Equal("3"))
+}
+
+func TestListDeploysFanOutWithTimeRange(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	service1Bytes := prepareDeploysResponse(t, []CortexDeploy{
+		{UUID: "1", Timestamp: "2025-01-01T00:00:00Z"},
+		{UUID: "2", Timestamp: "2025-02-01T00:00:00Z"},
+	}, 0, 1, 2)
+	service2Bytes := prepareDeploysResponse(t, []CortexDeploy{
+		{UUID: "3", Timestamp: "2025-03-01T00:00:00Z"},
+	}, 0, 1, 1)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/deploys"),
+			gh.RespondWith(http.StatusOK, service1Bytes, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service2/deploys"),
+			gh.RespondWith(http.StatusOK, service2Bytes, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexDeploy](100)
+
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	err := listDeploys(ctx, client, writer, []string{"service1", "service2"}, TimeRange{Start: &start})
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(HaveLen(2))
+	g.Expect(writer.Items[0].UUID).To(Equal("2"))
+	g.Expect(writer.Items[0].EntityTag).To(Equal("service1"))
+	g.Expect(writer.Items[1].UUID).To(Equal("3"))
+	g.Expect(writer.Items[1].EntityTag).To(Equal("service2"))
+}
+
+func TestListDeploysError(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/deploys"),
+			gh.RespondWith(http.StatusInternalServerError, "{\"details\": \"fake error on page 0\"}", nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexDeploy](100)
+
+	err := listDeploys(ctx, client, writer, []string{"service1"}, TimeRange{})
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(Equal("error from cortex API 500 Internal Server Error: {\"details\": \"fake error on page 0\"}"))
+}
+
+func TestTimeRangeFromQuals(t *testing.T) {
+	g := NewWithT(t)
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	timeRange := timeRangeFromQuals([]*quals.Qual{
+		timestampQual(quals.QualOperatorGreaterOrEqual, start),
+		timestampQual(quals.QualOperatorLess, end),
+	})
+	g.Expect(*timeRange.Start).To(Equal(start))
+	g.Expect(*timeRange.End).To(Equal(end))
+
+	g.Expect(timeRange.Contains("2024-12-31T23:59:59Z")).To(BeFalse())
+	g.Expect(timeRange.Contains("2025-01-01T00:00:00Z")).To(BeTrue())
+	g.Expect(timeRange.Contains("2025-01-15T10:00:00.123+01:00")).To(BeTrue())
+	g.Expect(timeRange.Contains("2025-02-01T00:00:01Z")).To(BeFalse())
+	g.Expect(timeRange.Contains("not a timestamp")).To(BeTrue())
+
+	timeRange = timeRangeFromQuals([]*quals.Qual{timestampQual(quals.QualOperatorEqual, start)})
+	g.Expect(*timeRange.Start).To(Equal(start))
+	g.Expect(*timeRange.End).To(Equal(start))
+
+	g.Expect(TimeRange{}.Contains("2025-01-01T00:00:00Z")).To(BeTrue())
+}
+
+func TestTimeRangeQueryParams(t *testing.T) {
+	g := NewWithT(t)
+	start := time.Date(2025, 1, 1, 12, 0, 0, 500000000, time.UTC)
+	end := time.Date(2025, 2, 1, 12, 0, 0, 500000000, time.FixedZone("CET", 3600))
+
+	// A fractional end is rounded up so nothing in the range is missed
+	g.Expect(TimeRange{Start: &start, End: &end}.QueryParams("startTime", "endTime")).To(Equal(map[string]string{
+		"startTime": "2025-01-01T12:00:00Z",
+		"endTime":   "2025-02-01T11:00:01Z",
+	}))
+
+	end = time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+	g.Expect(TimeRange{End: &end}.QueryParams("startTime", "endTime")).To(Equal(map[string]string{
+		"endTime": "2025-02-01T12:00:00Z",
+	}))
+	g.Expect(TimeRange{}.QueryParams("startTime", "endTime")).To(BeEmpty())
+}
+
+func timestampQual(operator string, value time.Time) *quals.Qual {
+	return &quals.Qual{
+		Column:   "timestamp",
+		Operator: operator,
+		Value: &proto.QualValue{
+			Value: &proto.QualValue_TimestampValue{TimestampValue: timestamppb.New(value)},
+		},
+	}
+}
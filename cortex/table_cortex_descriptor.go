@@ -3,12 +3,14 @@ package cortex
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 
 	"github.com/imroc/req/v3"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"gopkg.in/yaml.v3"
 )
 
 type CortexDescriptorsResponse struct {
@@ -18,31 +20,86 @@ type CortexDescriptorsResponse struct {
 	Total       int      `yaml:"total"`
 }
 
+// With yaml=true the API returns each descriptor as YAML text.
+type CortexDescriptorsYAMLResponse struct {
+	Descriptors []string `yaml:"descriptors"`
+	Page        int      `yaml:"page"`
+	TotalPages  int      `yaml:"totalPages"`
+	Total       int      `yaml:"total"`
+}
+
 func tableCortexDescriptor() *plugin.Table {
 	return &plugin.Table{
 		Name:        "cortex_descriptor",
 		Description: "Cortex openapi descriptors.",
 		List: &plugin.ListConfig{
 			Hydrate: listDescriptorsHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "yaml", Require: plugin.Optional},
+			},
 		},
-		Columns: []*plugin.Column{
-			{Name: "tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity."},
-			{Name: "title", Type: proto.ColumnType_STRING, Description: "Title."},
-			{Name: "description", Type: proto.ColumnType_STRING, Description: "Description."},
-			{Name: "type", Type: proto.ColumnType_STRING, Description: "Entity Type."},
-			{Name: "parents", Type: proto.ColumnType_JSON, Description: "Parent tags.", Transform: FromStructSlice[CortexTag]("Parents", "Tag")},
-			{Name: "groups", Type: proto.ColumnType_JSON, Description: "Groups, kind of like tags."},
-			{Name: "team", Type: proto.ColumnType_JSON, Description: "Raw team"},
-			{Name: "owners", Type: proto.ColumnType_JSON, Description: "Raw owner"},
-			{Name: "slack", Type: proto.ColumnType_JSON, Description: "Raw slack"},
-			{Name: "links", Type: proto.ColumnType_JSON, Description: "List of links", Transform: FromStructSlice[CortexLink]("Links", "Url")},
-			{Name: "metadata", Type: proto.ColumnType_JSON, Description: "Raw custom metadata", Transform: transform.FromField("CustomMetadata")},
-			{Name: "repository", Type: proto.ColumnType_STRING, Description: "Git repo full name", Transform: transform.FromField("Git.Github.Repository")},
-			{Name: "victorops", Type: proto.ColumnType_STRING, Description: "Victorops team slug", Transform: transform.FromField("Oncall.VictorOps.ID")},
-			{Name: "jira", Type: proto.ColumnType_JSON, Description: "List of jira projects", Transform: transform.FromField("Issues.Jira.Projects").Transform(transform.EnsureStringArray)},
-			{Name: "slos", Type: proto.ColumnType_JSON, Description: "SLOs from each integration if any", Transform: transform.FromField("SLOs")},
-			{Name: "static_analysis", Type: proto.ColumnType_JSON, Description: "Static analysis", Transform: transform.FromField("StaticAnalysis")},
+		Get: &plugin.GetConfig{
+			Hydrate: getDescriptorHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "tag", Require: plugin.Required},
+				{Name: "yaml", Require: plugin.Optional},
+			},
 		},
+		Columns: append(descriptorColumns(), []*plugin.Column{
+			{Name: "yaml", Type: proto.ColumnType_BOOL, Description: "Set to true to fetch the descriptors as YAML text into yaml_text", Transform: transform.FromMethod("HasYAML")},
+			{Name: "yaml_text", Type: proto.ColumnType_STRING, Description: "The descriptor as YAML text, only set when filtering on yaml = true", Transform: transform.FromField("YAML")},
+			{Name: "file", Type: proto.ColumnType_STRING, Description: "Path of the local descriptor file when the connection has descriptor_paths"},
+		}...),
+	}
+}
+
+// descriptorColumns are the columns of a decoded descriptor, shared by every
+// table whose rows embed a CortexInfo.
+func descriptorColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{Name: "tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity."},
+		{Name: "title", Type: proto.ColumnType_STRING, Description: "Title."},
+		{Name: "description", Type: proto.ColumnType_STRING, Description: "Description."},
+		{Name: "type", Type: proto.ColumnType_STRING, Description: "Entity Type."},
+		{Name: "parents", Type: proto.ColumnType_JSON, Description: "Parent tags.", Transform: FromStructSlice[CortexTag]("Parents", "Tag")},
+		{Name: "groups", Type: proto.ColumnType_JSON, Description: "Groups, kind of like tags."},
+		{Name: "team", Type: proto.ColumnType_JSON, Description: "Team configuration of a team entity: its identity provider groups and members"},
+		{Name: "owners", Type: proto.ColumnType_JSON, Description: "Owners: groups, individuals by email and Slack channels"},
+		{Name: "slack", Type: proto.ColumnType_JSON, Description: "Slack channels of the entity"},
+		{Name: "links", Type: proto.ColumnType_JSON, Description: "URLs of the links of the entity", Transform: FromStructSlice[CortexLink]("Link", "Url")},
+		{Name: "metadata", Type: proto.ColumnType_JSON, Description: "Custom metadata", Transform: transform.FromField("CustomMetadata")},
+		{Name: "git_provider", Type: proto.ColumnType_STRING, Description: "Git provider: github, gitlab, azure or bitbucket", Transform: FromGit("Git", "Provider")},
+		{Name: "repository", Type: proto.ColumnType_STRING, Description: "Git repo full name", Transform: FromGit("Git", "Repository")},
+		{Name: "base_path", Type: proto.ColumnType_STRING, Description: "Base path of the entity within a monorepo", Transform: FromGit("Git", "BasePath")},
+		{Name: "alias", Type: proto.ColumnType_STRING, Description: "Alias of the git integration account", Transform: FromGit("Git", "Alias")},
+		{Name: "repository_url", Type: proto.ColumnType_STRING, Description: "Browsable URL of the git repo, assuming github.com, gitlab.com or bitbucket.org", Transform: FromGit("Git", "RepositoryURL")},
+		{Name: "victorops", Type: proto.ColumnType_STRING, Description: "Victorops team slug", Transform: transform.FromField("Oncall.VictorOps.ID")},
+		{Name: "jira", Type: proto.ColumnType_JSON, Description: "List of jira projects", Transform: transform.FromField("Issues.Jira.Projects").Transform(transform.EnsureStringArray)},
+		{Name: "slos", Type: proto.ColumnType_JSON, Description: "SLOs from each integration if any", Transform: transform.FromField("SLOs")},
+		{Name: "static_analysis", Type: proto.ColumnType_JSON, Description: "Static analysis", Transform: transform.FromField("StaticAnalysis")},
+		{Name: "dependencies", Type: proto.ColumnType_JSON, Description: "Dependencies on other cortex entities", Transform: transform.FromField("Dependency.Cortex")},
+		{Name: "oncall", Type: proto.ColumnType_JSON, Description: "On-call integrations: pagerduty, opsgenie, victorops and xmatters", Transform: FromYAMLField("Oncall")},
+		{Name: "issues", Type: proto.ColumnType_JSON, Description: "Issue tracking integrations", Transform: FromYAMLField("Issues")},
+		{Name: "apm", Type: proto.ColumnType_JSON, Description: "APM integrations: datadog, newrelic, dynatrace and appdynamics", Transform: FromYAMLField("Apm")},
+		{Name: "dashboards", Type: proto.ColumnType_JSON, Description: "Embedded dashboards", Transform: FromYAMLField("Dashboards")},
+		{Name: "alerts", Type: proto.ColumnType_JSON, Description: "Alerting integrations", Transform: FromYAMLField("Alerts")},
+		{Name: "sentry", Type: proto.ColumnType_JSON, Description: "Sentry projects", Transform: FromYAMLField("Sentry")},
+		{Name: "bugsnag", Type: proto.ColumnType_JSON, Description: "Bugsnag project", Transform: FromYAMLField("Bugsnag")},
+		{Name: "rollbar", Type: proto.ColumnType_JSON, Description: "Rollbar project", Transform: FromYAMLField("Rollbar")},
+		{Name: "snyk", Type: proto.ColumnType_JSON, Description: "Snyk projects", Transform: FromYAMLField("Snyk")},
+		{Name: "k8s", Type: proto.ColumnType_JSON, Description: "Kubernetes deployments, argo rollouts, stateful sets and cron jobs", Transform: FromYAMLField("K8s")},
+		{Name: "infra", Type: proto.ColumnType_JSON, Description: "Infrastructure, like AWS ECS services and Cloud Control resources", Transform: FromYAMLField("Infra")},
+		{Name: "ci_cd", Type: proto.ColumnType_JSON, Description: "CI/CD integrations", Transform: FromYAMLField("CiCd")},
+		{Name: "microsoft_teams", Type: proto.ColumnType_JSON, Description: "Microsoft Teams channels", Transform: FromYAMLField("MicrosoftTeams")},
+		{Name: "circle_ci", Type: proto.ColumnType_JSON, Description: "CircleCI projects", Transform: FromYAMLField("CircleCI")},
+		{Name: "firehydrant", Type: proto.ColumnType_JSON, Description: "FireHydrant services", Transform: FromYAMLField("FireHydrant")},
+		{Name: "incident_io", Type: proto.ColumnType_JSON, Description: "incident.io custom fields", Transform: FromYAMLField("IncidentIO")},
+		{Name: "rootly", Type: proto.ColumnType_JSON, Description: "Rootly services", Transform: FromYAMLField("Rootly")},
+		{Name: "launch_darkly", Type: proto.ColumnType_JSON, Description: "LaunchDarkly projects and environments", Transform: FromYAMLField("LaunchDarkly")},
+		{Name: "extensions", Type: proto.ColumnType_JSON, Description: "x-cortex-* keys which are not in another column", Transform: transform.FromField("Extensions")},
+		{Name: "raw", Type: proto.ColumnType_JSON, Description: "The full original descriptor", Transform: transform.FromField("Raw")},
+		{Name: "parse_warnings", Type: proto.ColumnType_JSON, Description: "Problems decoding the descriptor, the affected fields are empty", Transform: transform.FromField("ParseWarnings")},
+		{Name: "source_format", Type: proto.ColumnType_STRING, Description: "Format the descriptor was read from: cortex, or backstage for a local catalog-info.yaml"},
 	}
 }
 
@@ -50,19 +107,36 @@ func listDescriptorsHydrator(ctx context.Context, d *plugin.QueryData, h *plugin
 	config := GetConfig(d.Connection)
 	client := CortexHTTPClient(ctx, config)
 	hydratorWriter := QueryDataWriter{d}
-	return nil, listDescriptors(ctx, client, &hydratorWriter)
+	asYAML := d.EqualsQuals["yaml"] != nil && d.EqualsQuals["yaml"].GetBoolValue()
+	return nil, listConfiguredDescriptors(ctx, config, client, &hydratorWriter, asYAML)
 }
 
-func listDescriptors(ctx context.Context, client *req.Client, writer HydratorWriter) error {
+func getDescriptorHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	tag := d.EqualsQuals["tag"].GetStringValue()
+	asYAML := d.EqualsQuals["yaml"] != nil && d.EqualsQuals["yaml"].GetBoolValue()
+	logger.Info("getDescriptorHydrator", "tag", tag, "yaml", asYAML)
+	descriptor, err := getConfiguredDescriptor(ctx, config, client, tag, asYAML)
+	if err != nil || descriptor == nil {
+		return nil, err
+	}
+	return descriptor.Info, nil
+}
+
+// listDescriptors streams the info of every descriptor. If asYAML is set the
+// descriptors are fetched as YAML text, which is kept in CortexInfo.YAML.
+func listDescriptors(ctx context.Context, client *req.Client, writer HydratorWriter, asYAML bool) error {
 	logger := plugin.Logger(ctx)
 	var response CortexDescriptorsResponse
 	var page int = 0
 	for {
-		logger.Debug("listDescriptors", "page", page)
+		logger.Debug("listDescriptors", "page", page, "yaml", asYAML)
 		resp := client.
 			Get("/api/v1/catalog/descriptors").
 			// Options
-			SetQueryParam("yaml", "false").
+			SetQueryParam("yaml", strconv.FormatBool(asYAML)).
 			// Pagination
 			SetQueryParam("pageSize", "1000").
 			SetQueryParam("page", strconv.Itoa(page)).
@@ -75,7 +149,12 @@ func listDescriptors(ctx context.Context, client *req.Client, writer HydratorWri
 		}
 
 		// Unmarshal the response and check for unmarshal errors
-		err := resp.Into(&response)
+		var err error
+		if asYAML {
+			response, err = parseDescriptorsYAMLResponse(resp)
+		} else {
+			err = resp.Into(&response)
+		}
 		if err != nil {
 			logger.Error("listDescriptors", "Error", err)
 			return err
@@ -83,6 +162,9 @@ func listDescriptors(ctx context.Context, client *req.Client, writer HydratorWri
 
 		// Stream each row from the response, stop if we hit the limit
 		for _, result := range response.Descriptors {
+			if len(result.Info.ParseWarnings) > 0 {
+				logger.Warn("listDescriptors", "tag", result.Info.Tag, "ParseWarnings", result.Info.ParseWarnings)
+			}
 			// send the item to steampipe
 			writer.StreamListItem(ctx, result.Info)
 			// Context can be cancelled due to manual cancellation or the limit has been hit
@@ -99,3 +181,71 @@ func listDescriptors(ctx context.Context, client *req.Client, writer HydratorWri
 	}
 	return nil
 }
+
+// parseDescriptorsYAMLResponse parses each YAML text descriptor of a response,
+// keeping the text in CortexInfo.YAML. Text which is not valid YAML is kept
+// with the error in ParseWarnings.
+func parseDescriptorsYAMLResponse(resp *req.Response) (CortexDescriptorsResponse, error) {
+	var yamlResponse CortexDescriptorsYAMLResponse
+	if err := resp.Into(&yamlResponse); err != nil {
+		return CortexDescriptorsResponse{}, err
+	}
+	response := CortexDescriptorsResponse{
+		Descriptors: make([]Cortex, 0, len(yamlResponse.Descriptors)),
+		Page:        yamlResponse.Page,
+		TotalPages:  yamlResponse.TotalPages,
+		Total:       yamlResponse.Total,
+	}
+	for _, text := range yamlResponse.Descriptors {
+		var descriptor Cortex
+		if err := yaml.Unmarshal([]byte(text), &descriptor); err != nil {
+			descriptor = Cortex{Info: CortexInfo{ParseWarnings: []string{err.Error()}}}
+		}
+		descriptor.Info.YAML = text
+		response.Descriptors = append(response.Descriptors, descriptor)
+	}
+	return response, nil
+}
+
+// getDescriptor returns the descriptor of a single entity, or nil if the
+// entity does not exist. If asYAML is set the descriptor is fetched as YAML
+// text, which is kept in CortexInfo.YAML.
+func getDescriptor(ctx context.Context, client *req.Client, tag string, asYAML bool) (*Cortex, error) {
+	logger := plugin.Logger(ctx)
+
+	resp := client.
+		Get("/api/v1/catalog/{tag}/openapi").
+		SetPathParam("tag", tag).
+		// Options
+		SetQueryParam("yaml", strconv.FormatBool(asYAML)).
+		Do(ctx)
+
+	// A missing entity is not an error, there is just no descriptor
+	if resp.GetStatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	// Check for HTTP errors
+	if resp.IsErrorState() {
+		logger.Error("getDescriptor", "tag", tag, "Status", resp.Status, "Body", resp.String())
+		return nil, fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+	}
+
+	// Unmarshal the response and check for unmarshal errors
+	var response Cortex
+	var err error
+	if asYAML {
+		err = yaml.Unmarshal(resp.Bytes(), &response)
+		response.Info.YAML = resp.String()
+	} else {
+		err = resp.Into(&response)
+	}
+	if err != nil {
+		logger.Error("getDescriptor", "tag", tag, "Error", err)
+		return nil, err
+	}
+	if len(response.Info.ParseWarnings) > 0 {
+		logger.Warn("getDescriptor", "tag", tag, "ParseWarnings", response.Info.ParseWarnings)
+	}
+	return &response, nil
+}
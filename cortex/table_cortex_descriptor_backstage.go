@@ -0,0 +1,66 @@
+package cortex
+
+import (
+	"context"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"gopkg.in/yaml.v3"
+)
+
+// Used to represent the Backstage entity of a single descriptor in the table
+type CortexDescriptorBackstageRow struct {
+	Tag            string
+	File           string
+	Entity         BackstageEntity
+	UnmappedFields []string
+}
+
+// BackstageYAML returns the entity as the text of a catalog-info.yaml file.
+func (r CortexDescriptorBackstageRow) BackstageYAML() (string, error) {
+	out, err := yaml.Marshal(r.Entity)
+	return string(out), err
+}
+
+func tableCortexDescriptorBackstage() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_descriptor_backstage",
+		Description: "Cortex descriptors converted to Backstage catalog entities.",
+		List: &plugin.ListConfig{
+			Hydrate: listDescriptorBackstageHydrator,
+		},
+		Columns: []*plugin.Column{
+			{Name: "tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the descriptor."},
+			{Name: "file", Type: proto.ColumnType_STRING, Description: "Path of the local descriptor file when the connection has descriptor_paths."},
+			{Name: "kind", Type: proto.ColumnType_STRING, Description: "Backstage kind: Component, System, Group or Resource.", Transform: transform.FromField("Entity.Kind")},
+			{Name: "entity", Type: proto.ColumnType_JSON, Description: "The Backstage entity, with the same keys as catalog-info.yaml.", Transform: FromYAMLField("Entity")},
+			{Name: "backstage_yaml", Type: proto.ColumnType_STRING, Description: "The Backstage entity as catalog-info.yaml text.", Transform: transform.FromMethod("BackstageYAML")},
+			{Name: "unmapped_fields", Type: proto.ColumnType_JSON, Description: "Descriptor keys which have no Backstage equivalent or were only partly converted, and required Backstage fields which could not be filled, like spec.owner.", Transform: transform.FromField("UnmappedFields")},
+		},
+	}
+}
+
+func listDescriptorBackstageHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+	return nil, listDescriptorBackstage(ctx, config, client, &hydratorWriter)
+}
+
+func listDescriptorBackstage(ctx context.Context, config *SteampipeConfig, client *req.Client, writer HydratorWriter) error {
+	descriptorWriter := ExpandWriter[CortexInfo]{
+		Writer: writer,
+		Expand: func(info CortexInfo) []interface{} {
+			entity, unmapped := BackstageEntityFromDescriptor(info)
+			return []interface{}{CortexDescriptorBackstageRow{
+				Tag:            info.Tag,
+				File:           info.File,
+				Entity:         entity,
+				UnmappedFields: unmapped,
+			}}
+		},
+	}
+	return listConfiguredDescriptors(ctx, config, client, &descriptorWriter, false)
+}
@@ -0,0 +1,85 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestTableCortexDescriptorBackstage(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexDescriptorBackstage()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_descriptor_backstage"))
+	g.Expect(table.Description).To(Equal("Cortex descriptors converted to Backstage catalog entities."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"tag", proto.ColumnType_STRING},
+		{"file", proto.ColumnType_STRING},
+		{"kind", proto.ColumnType_STRING},
+		{"entity", proto.ColumnType_JSON},
+		{"backstage_yaml", proto.ColumnType_STRING},
+		{"unmapped_fields", proto.ColumnType_JSON},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListDescriptorBackstage(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/descriptors"),
+			gh.RespondWith(http.StatusOK, `{
+				"descriptors": [
+					{"openapi": "3.0.1", "info": {"x-cortex-tag": "service1", "title": "Service 1", "x-cortex-owners": [{"type": "group", "name": "team-a"}], "x-cortex-oncall": {"pagerduty": {"id": "P123", "type": "SERVICE"}}}}
+				],
+				"page": 0,
+				"totalPages": 1,
+				"total": 1
+			}`, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexDescriptorBackstageRow](100)
+
+	err := listDescriptorBackstage(ctx, NewSteampipeConfig("fake_api_key", server.URL()), client, writer)
+	g.Expect(err).To(BeNil())
+	g.Expect(writer.Items).To(HaveLen(1))
+	g.Expect(writer.Items[0].Tag).To(Equal("service1"))
+	g.Expect(writer.Items[0].UnmappedFields).To(Equal([]string{"x-cortex-oncall"}))
+
+	text, err := writer.Items[0].BackstageYAML()
+	g.Expect(err).To(BeNil())
+	g.Expect(text).To(Equal(`apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+    name: service1
+    title: Service 1
+spec:
+    type: service
+    lifecycle: unknown
+    owner: group:team-a
+`))
+}
@@ -0,0 +1,169 @@
+package cortex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Used to represent a single version of a descriptor file in the table
+type CortexDescriptorHistoryRow struct {
+	CommitSHA   string
+	Author      string
+	AuthorEmail string
+	CommitDate  string
+	Path        string
+	CortexInfo
+}
+
+// A commit of the descriptor repository and the descriptor files it changed
+type descriptorCommit struct {
+	SHA         string
+	Author      string
+	AuthorEmail string
+	Date        string
+	Paths       []string
+}
+
+func tableCortexDescriptorHistory() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_descriptor_history",
+		Description: "Every version of each Cortex descriptor in the history of a local git repository.",
+		List: &plugin.ListConfig{
+			Hydrate: listDescriptorHistoryHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "commit_date", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+			},
+		},
+		Columns: append([]*plugin.Column{
+			{Name: "commit_sha", Type: proto.ColumnType_STRING, Description: "SHA of the commit which changed the descriptor.", Transform: transform.FromField("CommitSHA")},
+			{Name: "author", Type: proto.ColumnType_STRING, Description: "Name of the author of the commit."},
+			{Name: "author_email", Type: proto.ColumnType_STRING, Description: "Email of the author of the commit."},
+			{Name: "commit_date", Type: proto.ColumnType_TIMESTAMP, Description: "Time of the commit."},
+			{Name: "path", Type: proto.ColumnType_STRING, Description: "Path of the descriptor file within the repository."},
+		}, descriptorColumns()...),
+	}
+}
+
+func listDescriptorHistoryHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	hydratorWriter := QueryDataWriter{d}
+
+	if config.DescriptorRepository == nil || *config.DescriptorRepository == "" {
+		return nil, errors.New("descriptor_repository must be set in the connection config to use cortex_descriptor_history")
+	}
+
+	var timeRange TimeRange
+	if d.Quals["commit_date"] != nil {
+		timeRange = timeRangeFromQuals(d.Quals["commit_date"].Quals)
+	}
+
+	logger.Info("listDescriptorHistoryHydrator", "repository", *config.DescriptorRepository, "timeRange", timeRange)
+	return nil, listDescriptorHistory(ctx, *config.DescriptorRepository, &hydratorWriter, timeRange)
+}
+
+// listDescriptorHistory streams every version of each descriptor file in the
+// git history of the repository, newest first. Versions which delete a file
+// are not included.
+func listDescriptorHistory(ctx context.Context, repository string, writer HydratorWriter, timeRange TimeRange) error {
+	logger := plugin.Logger(ctx)
+
+	commits, err := listDescriptorCommits(ctx, repository, timeRange)
+	if err != nil {
+		logger.Error("listDescriptorHistory", "Error", err)
+		return err
+	}
+	logger.Debug("listDescriptorHistory", "commits", len(commits))
+
+	for _, commit := range commits {
+		for _, path := range commit.Paths {
+			text, err := runGit(ctx, repository, "show", commit.SHA+":"+path)
+			if err != nil {
+				logger.Error("listDescriptorHistory", "commit", commit.SHA, "path", path, "Error", err)
+				return err
+			}
+			infos, err := parseDescriptorDocuments(text)
+			if err != nil {
+				infos = []CortexInfo{{ParseWarnings: []string{err.Error()}}}
+			}
+			for _, info := range infos {
+				// send the item to steampipe
+				writer.StreamListItem(ctx, CortexDescriptorHistoryRow{
+					CommitSHA:   commit.SHA,
+					Author:      commit.Author,
+					AuthorEmail: commit.AuthorEmail,
+					CommitDate:  commit.Date,
+					Path:        path,
+					CortexInfo:  info,
+				})
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if writer.RowsRemaining(ctx) == 0 {
+					return nil
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// listDescriptorCommits returns the commits which added or changed a
+// descriptor file, newest first.
+func listDescriptorCommits(ctx context.Context, repository string, timeRange TimeRange) ([]descriptorCommit, error) {
+	// Each commit starts with a record separator, then the fields separated by
+	// unit separators, then the changed files on their own lines.
+	args := []string{"log", "--format=%x1e%H%x1f%an%x1f%ae%x1f%cI", "--name-only", "--no-renames", "--diff-filter=d"}
+	params := timeRange.QueryParams("since", "until")
+	for _, name := range []string{"since", "until"} {
+		if value, ok := params[name]; ok {
+			args = append(args, "--"+name+"="+value)
+		}
+	}
+	args = append(args, "--")
+	for _, name := range DescriptorFileNames {
+		args = append(args, ":(glob)**/"+name)
+	}
+	// Skip hidden directories, like findDescriptorFiles does for local files
+	args = append(args, ":(exclude,glob)**/.*/**")
+
+	out, err := runGit(ctx, repository, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []descriptorCommit
+	for _, record := range strings.Split(string(out), "\x1e") {
+		lines := strings.Split(strings.TrimSpace(record), "\n")
+		fields := strings.Split(lines[0], "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		commit := descriptorCommit{SHA: fields[0], Author: fields[1], AuthorEmail: fields[2], Date: fields[3]}
+		for _, line := range lines[1:] {
+			if line = strings.TrimSpace(line); line != "" {
+				commit.Paths = append(commit.Paths, line)
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// runGit runs a git command in the repository and returns its output.
+func runGit(ctx context.Context, repository string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repository, "-c", "core.quotePath=false"}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error from git %s: %s: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
@@ -0,0 +1,197 @@
+package cortex
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	. "github.com/onsi/gomega"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/context_key"
+)
+
+func TestTableCortexDescriptorHistory(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexDescriptorHistory()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_descriptor_history"))
+	g.Expect(table.Description).To(Equal("Every version of each Cortex descriptor in the history of a local git repository."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(1))
+	g.Expect(table.List.KeyColumns[0].Name).To(Equal("commit_date"))
+	g.Expect(table.List.KeyColumns[0].Require).To(Equal(plugin.Optional))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"commit_sha", proto.ColumnType_STRING},
+		{"author", proto.ColumnType_STRING},
+		{"author_email", proto.ColumnType_STRING},
+		{"commit_date", proto.ColumnType_TIMESTAMP},
+		{"path", proto.ColumnType_STRING},
+		{"tag", proto.ColumnType_STRING},
+		{"title", proto.ColumnType_STRING},
+		{"description", proto.ColumnType_STRING},
+		{"type", proto.ColumnType_STRING},
+		{"parents", proto.ColumnType_JSON},
+		{"groups", proto.ColumnType_JSON},
+		{"team", proto.ColumnType_JSON},
+		{"owners", proto.ColumnType_JSON},
+		{"slack", proto.ColumnType_JSON},
+		{"links", proto.ColumnType_JSON},
+		{"metadata", proto.ColumnType_JSON},
+		{"git_provider", proto.ColumnType_STRING},
+		{"repository", proto.ColumnType_STRING},
+		{"base_path", proto.ColumnType_STRING},
+		{"alias", proto.ColumnType_STRING},
+		{"repository_url", proto.ColumnType_STRING},
+		{"victorops", proto.ColumnType_STRING},
+		{"jira", proto.ColumnType_JSON},
+		{"slos", proto.ColumnType_JSON},
+		{"static_analysis", proto.ColumnType_JSON},
+		{"dependencies", proto.ColumnType_JSON},
+		{"oncall", proto.ColumnType_JSON},
+		{"issues", proto.ColumnType_JSON},
+		{"apm", proto.ColumnType_JSON},
+		{"dashboards", proto.ColumnType_JSON},
+		{"alerts", proto.ColumnType_JSON},
+		{"sentry", proto.ColumnType_JSON},
+		{"bugsnag", proto.ColumnType_JSON},
+		{"rollbar", proto.ColumnType_JSON},
+		{"snyk", proto.ColumnType_JSON},
+		{"k8s", proto.ColumnType_JSON},
+		{"infra", proto.ColumnType_JSON},
+		{"ci_cd", proto.ColumnType_JSON},
+		{"microsoft_teams", proto.ColumnType_JSON},
+		{"circle_ci", proto.ColumnType_JSON},
+		{"firehydrant", proto.ColumnType_JSON},
+		{"incident_io", proto.ColumnType_JSON},
+		{"rootly", proto.ColumnType_JSON},
+		{"launch_darkly", proto.ColumnType_JSON},
+		{"extensions", proto.ColumnType_JSON},
+		{"raw", proto.ColumnType_JSON},
+		{"parse_warnings", proto.ColumnType_JSON},
+		{"source_format", proto.ColumnType_STRING},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+// gitCommitFiles writes the files to the repository and commits them at the
+// given time, deleting any file with empty content.
+func gitCommitFiles(t *testing.T, dir string, author string, date string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if content == "" {
+			if err := os.Remove(path); err != nil {
+				t.Fatalf("Failed to remove file: %v", err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+	for _, args := range [][]string{{"add", "-A"}, {"commit", "-q", "-m", "Update descriptors"}} {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME="+author, "GIT_AUTHOR_EMAIL="+author+"@example.com", "GIT_AUTHOR_DATE="+date,
+			"GIT_COMMITTER_NAME="+author, "GIT_COMMITTER_EMAIL="+author+"@example.com", "GIT_COMMITTER_DATE="+date,
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("Failed to run git %v: %v: %s", args, err, out)
+		}
+	}
+}
+
+func TestListDescriptorHistory(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.WithValue(context.Background(), context_key.Logger, hclog.NewNullLogger())
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("Failed to init repository: %v: %s", err, out)
+	}
+
+	gitCommitFiles(t, dir, "alice", "2024-01-10T10:00:00Z", map[string]string{
+		"cortex.yaml":          "info:\n  x-cortex-tag: domain1\n  x-cortex-type: domain\n",
+		"service1/cortex.yaml": "info:\n  x-cortex-tag: service1\n  x-cortex-owners: [{type: group, name: team-a}]\n",
+		"README.md":            "Descriptors",
+		// Hidden directories are skipped, as they are for local files
+		".github/templates/cortex.yaml": "info:\n  x-cortex-tag: template\n",
+		"service2/.cache/cortex.yaml":   "info:\n  x-cortex-tag: cached\n",
+	})
+	gitCommitFiles(t, dir, "bob", "2024-06-01T10:00:00Z", map[string]string{
+		"service1/cortex.yaml": "info:\n  x-cortex-tag: service1\n  x-cortex-owners: [{type: group, name: team-b}]\n",
+	})
+	gitCommitFiles(t, dir, "carol", "2024-09-01T10:00:00Z", map[string]string{
+		"cortex.yaml": "",
+		"README.md":   "Descriptors of my-org",
+	})
+
+	writer := NewSliceWriter[CortexDescriptorHistoryRow](100)
+	err := listDescriptorHistory(ctx, dir, writer, TimeRange{})
+	g.Expect(err).To(BeNil())
+
+	// Newest first, without the deletion, hidden files or commits of other files
+	g.Expect(writer.Items).To(HaveLen(3))
+	g.Expect(writer.Items[0].Author).To(Equal("bob"))
+	g.Expect(writer.Items[0].AuthorEmail).To(Equal("bob@example.com"))
+	g.Expect(writer.Items[0].CommitDate).To(Equal("2024-06-01T10:00:00+00:00"))
+	g.Expect(writer.Items[0].CommitSHA).To(HaveLen(40))
+	g.Expect(writer.Items[0].Path).To(Equal("service1/cortex.yaml"))
+	g.Expect(writer.Items[0].Tag).To(Equal("service1"))
+	g.Expect(writer.Items[0].Owners).To(Equal([]CortexOwner{{Type: "group", Name: "team-b"}}))
+
+	g.Expect(writer.Items[1].Author).To(Equal("alice"))
+	g.Expect(writer.Items[1].Path).To(Equal("cortex.yaml"))
+	g.Expect(writer.Items[1].Tag).To(Equal("domain1"))
+	g.Expect(writer.Items[2].Path).To(Equal("service1/cortex.yaml"))
+	g.Expect(writer.Items[2].Owners).To(Equal([]CortexOwner{{Type: "group", Name: "team-a"}}))
+
+	// Filter on commit date
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	writer = NewSliceWriter[CortexDescriptorHistoryRow](100)
+	err = listDescriptorHistory(ctx, dir, writer, TimeRange{End: &start})
+	g.Expect(err).To(BeNil())
+	g.Expect(writer.Items).To(HaveLen(2))
+	g.Expect(writer.Items[0].Author).To(Equal("alice"))
+}
+
+func TestListDescriptorHistoryNotARepository(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.WithValue(context.Background(), context_key.Logger, hclog.NewNullLogger())
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	writer := NewSliceWriter[CortexDescriptorHistoryRow](100)
+	err := listDescriptorHistory(ctx, t.TempDir(), writer, TimeRange{})
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(ContainSubstring("error from git log"))
+}
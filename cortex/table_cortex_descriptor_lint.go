@@ -0,0 +1,68 @@
+package cortex
+
+import (
+	"context"
+	"math"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableCortexDescriptorLint() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_descriptor_lint",
+		Description: "Findings of the built-in lint rules for each Cortex descriptor.",
+		List: &plugin.ListConfig{
+			Hydrate: listDescriptorLintHydrator,
+		},
+		Columns: []*plugin.Column{
+			{Name: "tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the descriptor."},
+			{Name: "file", Type: proto.ColumnType_STRING, Description: "Path of the local descriptor file when the connection has descriptor_paths."},
+			{Name: "source_format", Type: proto.ColumnType_STRING, Description: "Format the descriptor was read from: cortex, or backstage for a local catalog-info.yaml."},
+			{Name: "rule_id", Type: proto.ColumnType_STRING, Description: "ID of the rule, e.g. missing-owners.", Transform: transform.FromField("RuleID")},
+			{Name: "severity", Type: proto.ColumnType_STRING, Description: "Severity of the rule: error or warning."},
+			{Name: "message", Type: proto.ColumnType_STRING, Description: "Description of the finding."},
+		},
+	}
+}
+
+func listDescriptorLintHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+	logger.Info("listDescriptorLintHydrator", "local", len(config.DescriptorPaths) > 0)
+	return nil, listDescriptorLint(ctx, config, client, &hydratorWriter)
+}
+
+func listDescriptorLint(ctx context.Context, config *SteampipeConfig, client *req.Client, writer HydratorWriter) error {
+	logger := plugin.Logger(ctx)
+
+	descriptors := SliceWriter[CortexInfo]{Limit: math.MaxInt64}
+	err := listConfiguredDescriptors(ctx, config, client, &descriptors, false)
+	if err != nil {
+		return err
+	}
+
+	// Local descriptors may refer to entities which are only in the catalog.
+	// This is best effort so that linting still works offline.
+	var knownTags []string
+	if len(config.DescriptorPaths) > 0 && config.ApiKey != nil && *config.ApiKey != "" {
+		knownTags, err = listEntityTags(ctx, client, "")
+		if err != nil {
+			logger.Warn("listDescriptorLint", "Error", err)
+		}
+	}
+
+	for _, row := range LintDescriptors(descriptors.Items, knownTags) {
+		// send the item to steampipe
+		writer.StreamListItem(ctx, row)
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if writer.RowsRemaining(ctx) == 0 {
+			return nil
+		}
+	}
+	return nil
+}
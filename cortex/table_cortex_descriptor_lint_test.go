@@ -0,0 +1,103 @@
+package cortex
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestTableCortexDescriptorLint(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexDescriptorLint()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_descriptor_lint"))
+	g.Expect(table.Description).To(Equal("Findings of the built-in lint rules for each Cortex descriptor."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"tag", proto.ColumnType_STRING},
+		{"file", proto.ColumnType_STRING},
+		{"source_format", proto.ColumnType_STRING},
+		{"rule_id", proto.ColumnType_STRING},
+		{"severity", proto.ColumnType_STRING},
+		{"message", proto.ColumnType_STRING},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListDescriptorLintAPI(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/descriptors"),
+			gh.RespondWith(http.StatusOK, `{
+				"descriptors": [
+					{"openapi": "3.0.1", "info": {"x-cortex-tag": "service1", "x-cortex-parents": [{"tag": "domain1"}]}}
+				],
+				"page": 0,
+				"totalPages": 1,
+				"total": 1
+			}`, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexDescriptorLintRow](100)
+
+	err := listDescriptorLint(ctx, NewSteampipeConfig("fake_api_key", server.URL()), client, writer)
+	g.Expect(err).To(BeNil())
+	g.Expect(writer.Items).To(Equal([]CortexDescriptorLintRow{
+		{Tag: "service1", SourceFormat: "cortex", RuleID: "missing-owners", Severity: "warning", Message: "no owners"},
+		{Tag: "service1", SourceFormat: "cortex", RuleID: "unknown-parent", Severity: "error", Message: `parent "domain1" does not exist`},
+	}))
+}
+
+func TestListDescriptorLintLocal(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	dir := writeDescriptorFiles(t, map[string]string{
+		"cortex.yaml": "openapi: 3.0.1\ninfo:\n  x-cortex-tag: service1\n  x-cortex-owners: [{type: group, name: team-a}]\n  x-cortex-parents: [{tag: domain1}, {tag: domain2}]\n",
+	})
+
+	// Tags of the catalog are used to check references
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog"),
+			gh.RespondWith(http.StatusOK, `{"entities": [{"tag": "domain1"}], "page": 0, "totalPages": 1, "total": 1}`, nil),
+		),
+	)
+	defer server.Close()
+
+	config := NewSteampipeConfig("fake_api_key", server.URL())
+	config.DescriptorPaths = []string{dir}
+
+	writer := NewSliceWriter[CortexDescriptorLintRow](100)
+
+	err := listDescriptorLint(ctx, config, client, writer)
+	g.Expect(err).To(BeNil())
+	g.Expect(writer.Items).To(Equal([]CortexDescriptorLintRow{
+		{Tag: "service1", File: filepath.Join(dir, "cortex.yaml"), SourceFormat: "cortex", RuleID: "unknown-parent", Severity: "error", Message: `parent "domain2" does not exist`},
+	}))
+}
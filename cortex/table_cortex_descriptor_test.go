@@ -3,6 +3,7 @@ package cortex
 import (
 	"context"
 	"net/http"
+	"path/filepath"
 	"testing"
 	_ "unsafe"
 
@@ -13,7 +14,9 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/context_key"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
 
 func setupTestServerAndClient(t *testing.T, handlers ...http.HandlerFunc) (context.Context, *ghttp.Server, *req.Client) {
@@ -62,6 +65,18 @@ func TestTableCortexDescriptor(t *testing.T) {
 	// Check list configuration.
 	g.Expect(table.List).ToNot(BeNil())
 	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(1))
+	g.Expect(table.List.KeyColumns[0].Name).To(Equal("yaml"))
+	g.Expect(table.List.KeyColumns[0].Require).To(Equal(plugin.Optional))
+
+	// Check get configuration.
+	g.Expect(table.Get).ToNot(BeNil())
+	g.Expect(table.Get.Hydrate).ToNot(BeNil())
+	g.Expect(table.Get.KeyColumns).To(HaveLen(2))
+	g.Expect(table.Get.KeyColumns[0].Name).To(Equal("tag"))
+	g.Expect(table.Get.KeyColumns[0].Require).To(Equal(plugin.Required))
+	g.Expect(table.Get.KeyColumns[1].Name).To(Equal("yaml"))
+	g.Expect(table.Get.KeyColumns[1].Require).To(Equal(plugin.Optional))
 
 	// Define expected columns.
 	expectedColumns := []struct {
@@ -79,11 +94,41 @@ func TestTableCortexDescriptor(t *testing.T) {
 		{"slack", proto.ColumnType_JSON},
 		{"links", proto.ColumnType_JSON},
 		{"metadata", proto.ColumnType_JSON},
+		{"git_provider", proto.ColumnType_STRING},
 		{"repository", proto.ColumnType_STRING},
+		{"base_path", proto.ColumnType_STRING},
+		{"alias", proto.ColumnType_STRING},
+		{"repository_url", proto.ColumnType_STRING},
 		{"victorops", proto.ColumnType_STRING},
 		{"jira", proto.ColumnType_JSON},
 		{"slos", proto.ColumnType_JSON},
 		{"static_analysis", proto.ColumnType_JSON},
+		{"dependencies", proto.ColumnType_JSON},
+		{"oncall", proto.ColumnType_JSON},
+		{"issues", proto.ColumnType_JSON},
+		{"apm", proto.ColumnType_JSON},
+		{"dashboards", proto.ColumnType_JSON},
+		{"alerts", proto.ColumnType_JSON},
+		{"sentry", proto.ColumnType_JSON},
+		{"bugsnag", proto.ColumnType_JSON},
+		{"rollbar", proto.ColumnType_JSON},
+		{"snyk", proto.ColumnType_JSON},
+		{"k8s", proto.ColumnType_JSON},
+		{"infra", proto.ColumnType_JSON},
+		{"ci_cd", proto.ColumnType_JSON},
+		{"microsoft_teams", proto.ColumnType_JSON},
+		{"circle_ci", proto.ColumnType_JSON},
+		{"firehydrant", proto.ColumnType_JSON},
+		{"incident_io", proto.ColumnType_JSON},
+		{"rootly", proto.ColumnType_JSON},
+		{"launch_darkly", proto.ColumnType_JSON},
+		{"extensions", proto.ColumnType_JSON},
+		{"raw", proto.ColumnType_JSON},
+		{"parse_warnings", proto.ColumnType_JSON},
+		{"source_format", proto.ColumnType_STRING},
+		{"yaml", proto.ColumnType_BOOL},
+		{"yaml_text", proto.ColumnType_STRING},
+		{"file", proto.ColumnType_STRING},
 	}
 
 	// Check that the table has the expected columns.
@@ -116,7 +161,7 @@ func TestListDescriptorsSinglePage(t *testing.T) {
 	writer := NewSliceWriter[CortexInfo](100)
 
 	// h is unused so we pass nil.
-	err := listDescriptors(ctx, client, writer)
+	err := listDescriptors(ctx, client, writer, false)
 	g.Expect(err).To(BeNil())
 
 	g.Expect(writer.Items).To(HaveLen(1))
@@ -157,7 +202,7 @@ func TestListDescriptorsMultiPage(t *testing.T) {
 	writer := NewSliceWriter[CortexInfo](100)
 
 	// Execute the listing of descriptors.
-	err := listDescriptors(ctx, client, writer)
+	err := listDescriptors(ctx, client, writer, false)
 	g.Expect(err).To(BeNil())
 
 	// Validate that all three descriptors were streamed.
@@ -185,7 +230,609 @@ func TestListDescriptorsError(t *testing.T) {
 	writer := NewSliceWriter[CortexInfo](100)
 
 	// Execute the listing of descriptors and expect an error.
-	err := listDescriptors(ctx, client, writer)
+	err := listDescriptors(ctx, client, writer, false)
 	g.Expect(err).ToNot(BeNil())
 	g.Expect(err.Error()).To(Equal("error from cortex API 500 Internal Server Error: {\"details\": \"fake error on page 0\"}"))
 }
+
+func TestListDescriptorsYAML(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	text := "openapi: 3.0.1\ninfo:\n  title: Service 1\n  x-cortex-tag: service1\n"
+	responseBytes, err := yaml.Marshal(CortexDescriptorsYAMLResponse{Descriptors: []string{text}, Page: 0, TotalPages: 1, Total: 1})
+	g.Expect(err).To(BeNil())
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/descriptors", "page=0&pageSize=1000&yaml=true"),
+			gh.RespondWith(http.StatusOK, responseBytes, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexInfo](100)
+
+	err = listDescriptors(ctx, client, writer, true)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(HaveLen(1))
+	g.Expect(writer.Items[0].Tag).To(Equal("service1"))
+	g.Expect(writer.Items[0].YAML).To(Equal(text))
+	g.Expect(writer.Items[0].HasYAML()).To(BeTrue())
+	g.Expect(writer.Items[0].Raw).To(HaveKeyWithValue("openapi", "3.0.1"))
+}
+
+func TestGetDescriptor(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	text := "openapi: 3.0.1\ninfo:\n  title: Service 1\n  x-cortex-tag: service1\n"
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/openapi", "yaml=false"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, `{"openapi": "3.0.1", "info": {"x-cortex-tag": "service1", "title": "Service 1"}}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/openapi", "yaml=true"),
+			gh.RespondWith(http.StatusOK, text, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/missing/openapi", "yaml=false"),
+			gh.RespondWith(http.StatusNotFound, `{"message": "Not found"}`, nil),
+		),
+	)
+	defer server.Close()
+
+	descriptor, err := getDescriptor(ctx, client, "service1", false)
+	g.Expect(err).To(BeNil())
+	g.Expect(descriptor.Info.Tag).To(Equal("service1"))
+	g.Expect(descriptor.Info.Title).To(Equal("Service 1"))
+	g.Expect(descriptor.Info.HasYAML()).To(BeFalse())
+
+	descriptor, err = getDescriptor(ctx, client, "service1", true)
+	g.Expect(err).To(BeNil())
+	g.Expect(descriptor.Info.Tag).To(Equal("service1"))
+	g.Expect(descriptor.Info.YAML).To(Equal(text))
+
+	descriptor, err = getDescriptor(ctx, client, "missing", false)
+	g.Expect(err).To(BeNil())
+	g.Expect(descriptor).To(BeNil())
+}
+
+func TestGetConfiguredDescriptorLocal(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := writeDescriptorFiles(t, map[string]string{
+		"service1/cortex.yaml": "info:\n  x-cortex-tag: service1\n",
+		"service2/cortex.yaml": "info:\n  x-cortex-tag: service2\n",
+	})
+
+	// No requests are made to the API
+	ctx, server, client := setupTestServerAndClient(t)
+	defer server.Close()
+
+	config := NewSteampipeConfig("fake_api_key", server.URL())
+	config.DescriptorPaths = []string{dir}
+
+	descriptor, err := getConfiguredDescriptor(ctx, config, client, "service2", false)
+	g.Expect(err).To(BeNil())
+	g.Expect(descriptor.Info.Tag).To(Equal("service2"))
+	g.Expect(descriptor.Info.File).To(Equal(filepath.Join(dir, "service2/cortex.yaml")))
+
+	descriptor, err = getConfiguredDescriptor(ctx, config, client, "missing", false)
+	g.Expect(err).To(BeNil())
+	g.Expect(descriptor).To(BeNil())
+}
+
+func TestCortexInfoExtensions(t *testing.T) {
+	g := NewWithT(t)
+
+	descriptor := `
+openapi: 3.0.1
+info:
+  title: Service 1
+  x-cortex-tag: service1
+  x-cortex-future-feature:
+    enabled: true
+  x-other-vendor: ignored
+paths: {}
+`
+	var cortex Cortex
+	g.Expect(yaml.Unmarshal([]byte(descriptor), &cortex)).To(Succeed())
+
+	g.Expect(cortex.Info.Extensions).To(Equal(map[string]interface{}{
+		"x-cortex-future-feature": map[string]interface{}{"enabled": true},
+	}))
+	g.Expect(cortex.Info.Raw).To(Equal(map[string]interface{}{
+		"openapi": "3.0.1",
+		"info": map[string]interface{}{
+			"title":                   "Service 1",
+			"x-cortex-tag":            "service1",
+			"x-cortex-future-feature": map[string]interface{}{"enabled": true},
+			"x-other-vendor":          "ignored",
+		},
+		"paths": map[string]interface{}{},
+	}))
+
+	// Extensions are written back when marshalling.
+	out, err := yaml.Marshal(cortex.Info)
+	g.Expect(err).To(BeNil())
+	var info map[string]interface{}
+	g.Expect(yaml.Unmarshal(out, &info)).To(Succeed())
+	g.Expect(info).To(Equal(map[string]interface{}{
+		"title":                   "Service 1",
+		"x-cortex-tag":            "service1",
+		"x-cortex-future-feature": map[string]interface{}{"enabled": true},
+	}))
+}
+
+func TestCortexInfoParseWarnings(t *testing.T) {
+	g := NewWithT(t)
+
+	descriptor := `
+openapi: 3.0.1
+info:
+  title: Service 1
+  x-cortex-tag: service1
+  x-cortex-owners: team-a
+  x-cortex-dependency:
+    cortex: service2
+  x-cortex-groups: [a, b]
+`
+	var cortex Cortex
+	g.Expect(yaml.Unmarshal([]byte(descriptor), &cortex)).To(Succeed())
+
+	// Fields of the wrong type are empty, the others are still decoded
+	g.Expect(cortex.Info.Tag).To(Equal("service1"))
+	g.Expect(cortex.Info.Groups).To(Equal([]string{"a", "b"}))
+	g.Expect(cortex.Info.Owners).To(BeNil())
+	g.Expect(cortex.Info.Dependency).To(Equal(CortexDependency{}))
+	g.Expect(cortex.Info.ParseWarnings).To(Equal([]string{
+		"x-cortex-owners: line 6: cannot unmarshal !!str `team-a` into []cortex.CortexOwner",
+		"x-cortex-dependency: line 8: cannot unmarshal !!str `service2` into []cortex.CortexDependencyCortex",
+	}))
+
+	// An info which is not a mapping is a warning on an otherwise empty info
+	cortex = Cortex{}
+	g.Expect(yaml.Unmarshal([]byte("openapi: 3.0.1\ninfo: service1\n"), &cortex)).To(Succeed())
+	g.Expect(cortex.Openapi).To(Equal("3.0.1"))
+	g.Expect(cortex.Info.Tag).To(BeEmpty())
+	g.Expect(cortex.Info.ParseWarnings).To(Equal([]string{"line 2: info must be a mapping"}))
+}
+
+func TestCortexInfoMergeKeys(t *testing.T) {
+	g := NewWithT(t)
+
+	descriptors := `
+x-defaults: &defaults
+  x-cortex-owners:
+    - type: group
+      name: team-a
+  x-cortex-groups: [default]
+x-tier: &tier
+  x-cortex-groups: [tier-1]
+  x-cortex-custom-metadata:
+    tier: 1
+services:
+  - openapi: 3.0.1
+    info:
+      <<: *defaults
+      title: Service 1
+      x-cortex-tag: service1
+  - openapi: 3.0.1
+    info:
+      <<: [*tier, *defaults]
+      title: Service 2
+      x-cortex-tag: service2
+      x-cortex-custom-metadata:
+        tier: 2
+`
+	var document struct {
+		Services []Cortex `yaml:"services"`
+	}
+	g.Expect(yaml.Unmarshal([]byte(descriptors), &document)).To(Succeed())
+	g.Expect(document.Services).To(HaveLen(2))
+
+	// Merged keys are decoded like any other key
+	service1 := document.Services[0].Info
+	g.Expect(service1.Tag).To(Equal("service1"))
+	g.Expect(service1.Owners).To(Equal([]CortexOwner{{Type: "group", Name: "team-a"}}))
+	g.Expect(service1.Groups).To(Equal([]string{"default"}))
+	g.Expect(service1.ParseWarnings).To(BeEmpty())
+
+	// Keys in the mapping override merged ones, and earlier merges override later ones
+	service2 := document.Services[1].Info
+	g.Expect(service2.Owners).To(Equal([]CortexOwner{{Type: "group", Name: "team-a"}}))
+	g.Expect(service2.Groups).To(Equal([]string{"tier-1"}))
+	g.Expect(service2.CustomMetadata).To(Equal(map[string]interface{}{"tier": 2}))
+	g.Expect(document.Services[1].Info.Raw).To(HaveKeyWithValue("info", HaveKeyWithValue("x-cortex-owners", HaveLen(1))))
+}
+
+func TestListDescriptorsParseWarnings(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	responseBytes, err := yaml.Marshal(CortexDescriptorsYAMLResponse{
+		Descriptors: []string{"info:\n  x-cortex-tag: [unclosed\n", "info:\n  x-cortex-tag: service2\n"},
+		Page:        0,
+		TotalPages:  1,
+		Total:       2,
+	})
+	g.Expect(err).To(BeNil())
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/descriptors", "page=0&pageSize=1000&yaml=false"),
+			gh.RespondWith(http.StatusOK, `{
+				"descriptors": [
+					{"openapi": "3.0.1", "info": {"x-cortex-tag": "service1", "x-cortex-link": {"url": "not a list"}}},
+					{"openapi": "3.0.1", "info": {"x-cortex-tag": "service2"}}
+				],
+				"page": 0,
+				"totalPages": 1,
+				"total": 2
+			}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/descriptors", "page=0&pageSize=1000&yaml=true"),
+			gh.RespondWith(http.StatusOK, responseBytes, nil),
+		),
+	)
+	defer server.Close()
+
+	// One bad descriptor does not fail the page
+	writer := NewSliceWriter[CortexInfo](100)
+	err = listDescriptors(ctx, client, writer, false)
+	g.Expect(err).To(BeNil())
+	g.Expect(writer.Items).To(HaveLen(2))
+	g.Expect(writer.Items[0].Tag).To(Equal("service1"))
+	g.Expect(writer.Items[0].ParseWarnings).To(HaveLen(1))
+	g.Expect(writer.Items[0].ParseWarnings[0]).To(HavePrefix("x-cortex-link: "))
+	g.Expect(writer.Items[1].ParseWarnings).To(BeEmpty())
+
+	// Nor does invalid YAML text
+	writer = NewSliceWriter[CortexInfo](100)
+	err = listDescriptors(ctx, client, writer, true)
+	g.Expect(err).To(BeNil())
+	g.Expect(writer.Items).To(HaveLen(2))
+	g.Expect(writer.Items[0].ParseWarnings).To(HaveLen(1))
+	g.Expect(writer.Items[0].YAML).To(Equal("info:\n  x-cortex-tag: [unclosed\n"))
+	g.Expect(writer.Items[1].Tag).To(Equal("service2"))
+}
+
+func TestCortexGitNormalize(t *testing.T) {
+	testCases := []struct {
+		name       string
+		descriptor string
+		expected   CortexEntityGit
+	}{
+		{
+			name:       "github",
+			descriptor: "github: {repository: org/repo, basepath: svc, alias: main}",
+			expected:   CortexEntityGit{Provider: "github", Repository: "org/repo", BasePath: "svc", Alias: "main", RepositoryURL: "https://github.com/org/repo"},
+		},
+		{
+			name:       "gitlab",
+			descriptor: "gitlab: {repository: group/sub/repo}",
+			expected:   CortexEntityGit{Provider: "gitlab", Repository: "group/sub/repo", RepositoryURL: "https://gitlab.com/group/sub/repo"},
+		},
+		{
+			name:       "azure",
+			descriptor: "azure: {project: proj, repository: repo}",
+			expected:   CortexEntityGit{Provider: "azure", Repository: "proj/repo"},
+		},
+		{
+			name:       "bitbucket",
+			descriptor: "bitbucket: {repository: team/repo}",
+			expected:   CortexEntityGit{Provider: "bitbucket", Repository: "team/repo", RepositoryURL: "https://bitbucket.org/team/repo"},
+		},
+		{
+			name:       "none",
+			descriptor: "{}",
+			expected:   CortexEntityGit{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var git CortexGit
+			g.Expect(yaml.Unmarshal([]byte(tc.descriptor), &git)).To(Succeed())
+			g.Expect(git.Normalize()).To(Equal(tc.expected))
+		})
+	}
+}
+
+// A descriptor using every block of the descriptor spec which is modelled.
+const fullDescriptor = `
+openapi: 3.0.1
+info:
+  title: Service 1
+  description: The first service
+  x-cortex-tag: service1
+  x-cortex-type: service
+  x-cortex-parents:
+    - tag: domain1
+  x-cortex-groups:
+    - tier-1
+  x-cortex-owners:
+    - type: group
+      name: team-a
+      provider: CORTEX
+      inheritance: APPEND
+    - type: email
+      email: alice@example.com
+    - type: slack
+      channel: team-a
+      notificationsEnabled: true
+  x-cortex-team:
+    groups:
+      - name: team-a
+        provider: OKTA
+    members:
+      - name: Alice
+        email: alice@example.com
+        notificationsEnabled: true
+        role: owner
+  x-cortex-slack:
+    channels:
+      - name: service1-alerts
+        notificationsEnabled: true
+        description: Alerts
+  x-cortex-microsoft-teams:
+    - name: service1
+      notificationsEnabled: false
+  x-cortex-link:
+    - name: Runbook
+      type: runbook
+      url: https://example.com/runbook
+  x-cortex-custom-metadata:
+    cost_center: CC-1
+    replicas: 3
+  x-cortex-git:
+    github:
+      repository: org/service1
+      basepath: services/service1
+  x-cortex-oncall:
+    pagerduty:
+      id: PABC123
+      type: SERVICE
+    opsgenie:
+      id: schedule-1
+      type: SCHEDULE
+    victorops:
+      id: team-slug
+      type: SCHEDULE
+  x-cortex-issues:
+    jira:
+      projects: [SVC]
+      labels: [service1]
+      components: [backend]
+      defaultJql: project = SVC
+  x-cortex-dependency:
+    cortex:
+      - tag: service2
+        method: GET
+        path: /users
+        description: Reads users
+    aws:
+      tags:
+        - key: service
+          value: service1
+  x-cortex-slos:
+    datadog:
+      - id: abc
+    dynatrace:
+      - id: def
+    lightstep:
+      - streamId: stream1
+        targets:
+          latency:
+            - percentile: 0.99
+              target: 200
+              slo: 0.995
+    newrelic:
+      - id: ghi
+        alias: prod
+    prometheus:
+      - errorQuery: sum(rate(errors[5m]))
+        totalQuery: sum(rate(requests[5m]))
+        slo: 99.9
+        name: availability
+    signalfx:
+      - query: sf_metric:requests
+        rollup: sum
+        target: 99.5
+        lookback: P1W
+        operation: "<="
+    sumologic:
+      - id: jkl
+  x-cortex-static-analysis:
+    sonarqube:
+      project: org:service1
+    codecov:
+      owner: org
+      repo: service1
+      provider: GITHUB
+      flag: unit
+    mend:
+      applicationIds: [app1]
+      projectIds: [project1]
+    veracode:
+      applicationNames: [service1]
+      sandboxes:
+        - applicationName: service1
+          sandboxName: staging
+    checkmarx:
+      projects:
+        - projectId: 1234
+        - projectName: service1
+  x-cortex-apm:
+    datadog:
+      monitors: [123, 456]
+      serviceTags:
+        - key: service
+          value: service1
+    newrelic:
+      applications:
+        - applicationId: 789
+          alias: prod
+      tags:
+        - tag: service
+          value: service1
+    dynatrace:
+      entityIds: [SERVICE-1]
+      entityNameMatchers: [service1]
+    appdynamics:
+      applications:
+        - applicationId: 42
+  x-cortex-dashboards:
+    embeds:
+      - type: grafana
+        url: https://grafana.example.com/d/service1
+  x-cortex-alerts:
+    - type: opsgenie
+      tag: service
+      value: service1
+  x-cortex-sentry:
+    projects:
+      - name: service1
+  x-cortex-bugsnag:
+    project: service1
+  x-cortex-rollbar:
+    project: service1
+  x-cortex-snyk:
+    projects:
+      - organization: org
+        projectId: 01234567-89ab-cdef-0123-456789abcdef
+        source: CODE
+  x-cortex-k8s:
+    deployment:
+      - identifier: default/service1
+        cluster: prod
+    argorollout:
+      - identifier: default/service1-rollout
+    statefulset:
+      - identifier: default/service1-db
+    cronjob:
+      - identifier: default/service1-cleanup
+  x-cortex-infra:
+    aws:
+      ecs:
+        - clusterArn: arn:aws:ecs:us-east-1:123456789012:cluster/prod
+          serviceArn: arn:aws:ecs:us-east-1:123456789012:service/prod/service1
+      cloudControl:
+        - type: AWS::SQS::Queue
+          region: us-east-1
+          accountId: "123456789012"
+          identifier: service1-queue
+  x-cortex-ci-cd:
+    buildkite:
+      pipelines:
+        - slug: service1
+      tags:
+        - tag: service1
+  x-cortex-circle-ci:
+    projects:
+      - projectSlug: github/my-org/service1
+        alias: default
+  x-cortex-firehydrant:
+    services:
+      - identifier: ASDF1234
+        identifierType: ID
+  x-cortex-incident-io:
+    customFields:
+      - name: Entity
+        value: service1
+      - id: 01FCNDV6P870EA6S7TK1DSYDG0
+        value: service1
+        alias: other-account
+  x-cortex-rootly:
+    services:
+      - id: ASDF1234
+      - slug: service1
+        alias: other-account
+  x-cortex-launch-darkly:
+    projects:
+      - key: service1
+        environments:
+          - environmentName: production
+          - environmentName: staging
+      - tag: service1
+        alias: other-account
+`
+
+func TestCortexDescriptorRoundTrip(t *testing.T) {
+	g := NewWithT(t)
+
+	var descriptor Cortex
+	g.Expect(yaml.Unmarshal([]byte(fullDescriptor), &descriptor)).To(Succeed())
+
+	g.Expect(descriptor.Info.Oncall.PagerDuty).To(Equal(CortexOncallProvider{Type: "SERVICE", ID: "PABC123"}))
+	g.Expect(descriptor.Info.SLOs.Prometheus[0].SLO).To(Equal(99.9))
+	g.Expect(descriptor.Info.Apm.Datadog.Monitors).To(Equal([]int64{123, 456}))
+	g.Expect(descriptor.Info.Infra.AWS.CloudControl[0].AccountID).To(Equal("123456789012"))
+	g.Expect(descriptor.Info.Owners[2]).To(Equal(CortexOwner{Type: "slack", Channel: "team-a", NotificationsEnabled: true}))
+	g.Expect(descriptor.Info.LaunchDarkly.Projects[0].Environments).To(HaveLen(2))
+	g.Expect(descriptor.Info.Extensions).To(BeEmpty())
+
+	// Every field of the descriptor survives a round trip through the models.
+	out, err := yaml.Marshal(descriptor)
+	g.Expect(err).To(BeNil())
+
+	var expected, actual interface{}
+	g.Expect(yaml.Unmarshal([]byte(fullDescriptor), &expected)).To(Succeed())
+	g.Expect(yaml.Unmarshal(out, &actual)).To(Succeed())
+	g.Expect(actual).To(Equal(expected))
+}
+
+func TestDescriptorColumnsLinks(t *testing.T) {
+	g := NewWithT(t)
+
+	var links *plugin.Column
+	for _, column := range descriptorColumns() {
+		if column.Name == "links" {
+			links = column
+		}
+	}
+	g.Expect(links).ToNot(BeNil())
+
+	info := CortexInfo{
+		Tag: "service1",
+		Link: []CortexLink{
+			{Name: "Docs", Type: "documentation", Url: "https://example.com/docs"},
+			{Name: "Runbook", Type: "runbook", Url: "https://example.com/runbook"},
+		},
+	}
+	ctx := context.Background()
+	value, err := links.Transform.Execute(ctx, &transform.TransformData{HydrateItem: info})
+	g.Expect(err).To(BeNil())
+	g.Expect(value).To(Equal([]string{"https://example.com/docs", "https://example.com/runbook"}))
+
+	// Rows which embed the info, like the history, have the same links
+	value, err = links.Transform.Execute(ctx, &transform.TransformData{HydrateItem: CortexDescriptorHistoryRow{CortexInfo: info}})
+	g.Expect(err).To(BeNil())
+	g.Expect(value).To(Equal([]string{"https://example.com/docs", "https://example.com/runbook"}))
+}
+
+func TestFromYAMLField(t *testing.T) {
+	g := NewWithT(t)
+
+	var descriptor Cortex
+	g.Expect(yaml.Unmarshal([]byte(fullDescriptor), &descriptor)).To(Succeed())
+
+	ctx := context.Background()
+	apm, err := FromYAMLField("Apm").Execute(ctx, &transform.TransformData{HydrateItem: descriptor.Info})
+	g.Expect(err).To(BeNil())
+	g.Expect(apm).To(HaveKeyWithValue("dynatrace", map[string]interface{}{
+		"entityIds":          []interface{}{"SERVICE-1"},
+		"entityNameMatchers": []interface{}{"service1"},
+	}))
+
+	// Blocks which are not configured are null rather than an empty object.
+	rollbar, err := FromYAMLField("Rollbar").Execute(ctx, &transform.TransformData{HydrateItem: CortexInfo{}})
+	g.Expect(err).To(BeNil())
+	g.Expect(rollbar).To(BeNil())
+}
@@ -3,6 +3,7 @@ package cortex
 import (
 	"context"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -11,28 +12,25 @@ import (
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"gopkg.in/yaml.v3"
 )
 
-type ScalarOrMap struct {
-	Scalar interface{}
-	Map    map[string]interface{}
+// MetadataValue holds a custom metadata value of any JSON shape: a scalar, a list
+// (including lists of objects) or a nested object.
+type MetadataValue struct {
+	Raw interface{}
 }
 
-func (s *ScalarOrMap) UnmarshalYAML(unmarshal func(interface{}) error) error {
-	if err := unmarshal(&s.Map); err == nil {
-		return nil
-	}
-	if err := unmarshal(&s.Scalar); err != nil {
-		return err
-	}
-	return nil
+func (m *MetadataValue) UnmarshalYAML(value *yaml.Node) error {
+	return value.Decode(&m.Raw)
 }
 
-func (s *ScalarOrMap) Value() interface{} {
-	if s.Scalar != nil {
-		return s.Scalar
-	}
-	return s.Map
+func (m MetadataValue) MarshalYAML() (interface{}, error) {
+	return m.Raw, nil
+}
+
+func (m *MetadataValue) Value() interface{} {
+	return m.Raw
 }
 
 type CortexEntityResponse struct {
@@ -53,18 +51,40 @@ type CortexEntityElement struct {
 	LastUpdated string                        `yaml:"lastUpdated"`
 	Links       []CortexLink                  `yaml:"links"`
 	Archived    bool                          `yaml:"isArchived"`
-	Git         CortexGithub                  `yaml:"git"`
+	Git         CortexEntityGit               `yaml:"git"`
 	Slack       []CortexSlackChannel          `yaml:"slackChannels"`
 	Owners      CortexEntityOwners            `yaml:"owners"`
 }
 
+type CortexEntityGit struct {
+	Provider      string `yaml:"provider,omitempty"`
+	Repository    string `yaml:"repository,omitempty"`
+	BasePath      string `yaml:"basepath,omitempty"`
+	Alias         string `yaml:"alias,omitempty"`
+	RepositoryURL string `yaml:"repositoryUrl,omitempty"`
+}
+
+// Normalize lower cases the provider. The repository URL from the API is kept,
+// as it is right for self hosted instances too, and only when it is missing is
+// one built for the SaaS version of the provider.
+func (g CortexEntityGit) Normalize() CortexEntityGit {
+	g.Provider = strings.ToLower(strings.ReplaceAll(g.Provider, "_", "-"))
+	if g.Provider == "azure-devops" {
+		g.Provider = "azure"
+	}
+	if g.RepositoryURL == "" {
+		g.RepositoryURL = gitRepositoryURL(g.Provider, g.Repository)
+	}
+	return g
+}
+
 type CortexEntityElementHierarchy struct {
 	Parents []CortexTag `yaml:"parents"`
 }
 
 type CortexEntityElementMetadata struct {
-	Key   string      `yaml:"key"`
-	Value ScalarOrMap `yaml:"value"`
+	Key   string        `yaml:"key"`
+	Value MetadataValue `yaml:"value"`
 }
 
 type CortexEntityOwners struct {
@@ -103,8 +123,12 @@ func tableCortexEntity() *plugin.Table {
 			{Name: "last_updated", Type: proto.ColumnType_TIMESTAMP, Description: "Last updated time."},
 			{Name: "links", Type: proto.ColumnType_JSON, Description: "List of links", Transform: FromStructSlice[CortexLink]("Links", "Url")},
 			{Name: "archived", Type: proto.ColumnType_BOOL, Description: "Is archived."},
-			{Name: "repository", Type: proto.ColumnType_STRING, Description: "Git repo full name", Transform: transform.FromField("Git.Repository")},
-			{Name: "slack_channels", Type: proto.ColumnType_JSON, Description: "List of string slack channels"},
+			{Name: "git_provider", Type: proto.ColumnType_STRING, Description: "Git provider: github, gitlab, azure or bitbucket", Transform: FromGit("Git", "Provider")},
+			{Name: "repository", Type: proto.ColumnType_STRING, Description: "Git repo full name", Transform: FromGit("Git", "Repository")},
+			{Name: "base_path", Type: proto.ColumnType_STRING, Description: "Base path of the entity within a monorepo", Transform: FromGit("Git", "BasePath")},
+			{Name: "alias", Type: proto.ColumnType_STRING, Description: "Alias of the git integration account", Transform: FromGit("Git", "Alias")},
+			{Name: "repository_url", Type: proto.ColumnType_STRING, Description: "Browsable URL of the git repo from the API, or built for github.com, gitlab.com or bitbucket.org when missing", Transform: FromGit("Git", "RepositoryURL")},
+			{Name: "slack_channels", Type: proto.ColumnType_JSON, Description: "List of slack channels, each with name, notificationsEnabled and description."},
 			{Name: "owner_teams", Type: proto.ColumnType_JSON, Description: "List of owning team tags", Transform: FromStructSlice[CortexEntityOwnersTeam]("Owners.Teams", "Tag")},
 			{Name: "owner_individuals", Type: proto.ColumnType_JSON, Description: "List of owning individuals emails", Transform: FromStructSlice[CortexEntityOwnersIndividual]("Owners.Individuals", "Email")},
 		},
@@ -196,6 +220,31 @@ func listEntities(ctx context.Context, client *req.Client, writer HydratorWriter
 	return nil
 }
 
+// listEntityTags returns the tag of every non-archived entity of the given
+// types, so that tables backed by a per-entity API can fan out across the catalog.
+func listEntityTags(ctx context.Context, client *req.Client, types string) ([]string, error) {
+	entities := SliceWriter[CortexEntityElement]{Limit: math.MaxInt64}
+	if err := listEntities(ctx, client, &entities, "false", types, ""); err != nil {
+		return nil, err
+	}
+	tags := make([]string, 0, len(entities.Items))
+	for _, entity := range entities.Items {
+		tags = append(tags, entity.Tag)
+	}
+	return tags, nil
+}
+
+// getEntityTags returns the tag from the equals qual on the column if given,
+// otherwise the tag of every entity in the catalog.
+func getEntityTags(ctx context.Context, d *plugin.QueryData, client *req.Client, column string) ([]string, error) {
+	if d.EqualsQuals[column] != nil {
+		if tag := d.EqualsQuals[column].GetStringValue(); tag != "" {
+			return []string{tag}, nil
+		}
+	}
+	return listEntityTags(ctx, client, "")
+}
+
 // buildListFilter constructs a comma-separated string of group filters from the provided quals.
 func buildListFilter(groupQuals []*quals.Qual) string {
 	var values []string
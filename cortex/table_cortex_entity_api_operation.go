@@ -0,0 +1,192 @@
+package cortex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ApiOperationSourceDocumentation = "documentation"
+	ApiOperationSourceDescriptor    = "descriptor"
+)
+
+// Returned, wrapped, when the stored spec of an entity cannot be parsed
+var errInvalidApiDocumentation = errors.New("invalid API documentation")
+
+// The documentation endpoint returns the stored spec as a JSON or YAML string.
+type CortexApiDocumentationResponse struct {
+	Spec string `yaml:"spec"`
+}
+
+// Used to represent a single path + method of an entity's API in the table
+type CortexEntityApiOperationRow struct {
+	EntityTag string
+	Path      string
+	Method    string
+	Source    string
+	CortexOperation
+}
+
+func tableCortexEntityApiOperation() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_entity_api_operation",
+		Description: "Operations of the OpenAPI spec of each Cortex entity.",
+		List: &plugin.ListConfig{
+			Hydrate: listEntityApiOperationsHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "entity_tag", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "entity_tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity."},
+			{Name: "path", Type: proto.ColumnType_STRING, Description: "Path of the operation, e.g. /users/{id}."},
+			{Name: "method", Type: proto.ColumnType_STRING, Description: "Upper case HTTP method of the operation."},
+			{Name: "operation_id", Type: proto.ColumnType_STRING, Description: "The operationId of the operation.", Transform: transform.FromField("OperationID")},
+			{Name: "summary", Type: proto.ColumnType_STRING, Description: "Summary."},
+			{Name: "description", Type: proto.ColumnType_STRING, Description: "Description."},
+			{Name: "tags", Type: proto.ColumnType_JSON, Description: "OpenAPI tags of the operation.", Transform: transform.FromField("Tags")},
+			{Name: "deprecated", Type: proto.ColumnType_BOOL, Description: "Whether the operation is deprecated.", Transform: transform.FromField("Deprecated")},
+			{Name: "source", Type: proto.ColumnType_STRING, Description: "Where the spec came from: documentation, or descriptor if the entity has no API documentation."},
+		},
+	}
+}
+
+func listEntityApiOperationsHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+
+	tags, err := getEntityTags(ctx, d, client, "entity_tag")
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("listEntityApiOperationsHydrator", "tags", len(tags))
+	return nil, listEntityApiOperations(ctx, client, &hydratorWriter, tags)
+}
+
+func listEntityApiOperations(ctx context.Context, client *req.Client, writer HydratorWriter, tags []string) error {
+	logger := plugin.Logger(ctx)
+
+	for _, tag := range tags {
+		source := ApiOperationSourceDocumentation
+		paths, err := getApiDocumentationPaths(ctx, client, tag)
+		// An invalid spec should not hide the operations of other entities
+		if errors.Is(err, errInvalidApiDocumentation) {
+			logger.Warn("listEntityApiOperations", "tag", tag, "Error", err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		// Fall back to the paths of the descriptor
+		if paths == nil {
+			source = ApiOperationSourceDescriptor
+			descriptor, err := getDescriptor(ctx, client, tag, false)
+			if err != nil {
+				return err
+			}
+			if descriptor != nil {
+				paths = descriptor.Paths
+			}
+		}
+		logger.Debug("listEntityApiOperations", "tag", tag, "source", source, "paths", len(paths))
+
+		for _, row := range apiOperationRows(tag, source, paths) {
+			// send the item to steampipe
+			writer.StreamListItem(ctx, row)
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if writer.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// getApiDocumentationPaths returns the paths of the OpenAPI spec stored for the
+// entity, or nil if the entity has no API documentation. A spec which cannot
+// be parsed is an errInvalidApiDocumentation.
+func getApiDocumentationPaths(ctx context.Context, client *req.Client, tag string) (CortexPaths, error) {
+	logger := plugin.Logger(ctx)
+
+	resp := client.
+		Get("/api/v1/catalog/{tag}/documentation/openapi").
+		SetPathParam("tag", tag).
+		Do(ctx)
+
+	if resp.GetStatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	// Check for HTTP errors
+	if resp.IsErrorState() {
+		logger.Error("getApiDocumentationPaths", "tag", tag, "Status", resp.Status, "Body", resp.String())
+		return nil, fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+	}
+
+	// Unmarshal the response and check for unmarshal errors
+	var response CortexApiDocumentationResponse
+	err := resp.Into(&response)
+	if err != nil {
+		logger.Error("getApiDocumentationPaths", "tag", tag, "Error", err)
+		return nil, err
+	}
+	if response.Spec == "" {
+		return nil, nil
+	}
+
+	// JSON is valid YAML, so either format of spec can be parsed the same way.
+	// Only the paths are decoded, the info of an API spec is not a descriptor.
+	var spec struct {
+		Paths CortexPaths `yaml:"paths"`
+	}
+	err = yaml.Unmarshal([]byte(response.Spec), &spec)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %s: %s", errInvalidApiDocumentation, tag, err)
+	}
+	if spec.Paths == nil {
+		return CortexPaths{}, nil
+	}
+	return spec.Paths, nil
+}
+
+// apiOperationRows flattens the paths into one row per path + method, sorted
+// by path and then in the order the methods are listed by OpenAPI.
+func apiOperationRows(tag string, source string, paths CortexPaths) []CortexEntityApiOperationRow {
+	sortedPaths := make([]string, 0, len(paths))
+	for path := range paths {
+		sortedPaths = append(sortedPaths, path)
+	}
+	sort.Strings(sortedPaths)
+
+	var rows []CortexEntityApiOperationRow
+	for _, path := range sortedPaths {
+		for _, method := range OpenAPIMethods {
+			operation, ok := paths[path][method]
+			if !ok {
+				continue
+			}
+			rows = append(rows, CortexEntityApiOperationRow{
+				EntityTag:       tag,
+				Path:            path,
+				Method:          strings.ToUpper(method),
+				Source:          source,
+				CortexOperation: operation,
+			})
+		}
+	}
+	return rows
+}
@@ -0,0 +1,173 @@
+package cortex
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTableCortexEntityApiOperation(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexEntityApiOperation()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_entity_api_operation"))
+	g.Expect(table.Description).To(Equal("Operations of the OpenAPI spec of each Cortex entity."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(1))
+	g.Expect(table.List.KeyColumns[0].Name).To(Equal("entity_tag"))
+	g.Expect(table.List.KeyColumns[0].Require).To(Equal(plugin.Optional))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"entity_tag", proto.ColumnType_STRING},
+		{"path", proto.ColumnType_STRING},
+		{"method", proto.ColumnType_STRING},
+		{"operation_id", proto.ColumnType_STRING},
+		{"summary", proto.ColumnType_STRING},
+		{"description", proto.ColumnType_STRING},
+		{"tags", proto.ColumnType_JSON},
+		{"deprecated", proto.ColumnType_BOOL},
+		{"source", proto.ColumnType_STRING},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestCortexPathsUnmarshal(t *testing.T) {
+	g := NewWithT(t)
+
+	var descriptor Cortex
+	err := yaml.Unmarshal([]byte(`
+openapi: 3.0.1
+info:
+  title: Service 1
+  x-cortex-tag: service1
+paths:
+  /users/{id}:
+    summary: A user
+    parameters:
+      - name: id
+        in: path
+    get:
+      operationId: getUser
+      tags: [users]
+    delete:
+      operationId: deleteUser
+      deprecated: true
+`), &descriptor)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(descriptor.Info.Tag).To(Equal("service1"))
+	g.Expect(descriptor.Paths).To(Equal(CortexPaths{
+		"/users/{id}": CortexPathItem{
+			"get":    {OperationID: "getUser", Tags: []string{"users"}},
+			"delete": {OperationID: "deleteUser", Deprecated: true},
+		},
+	}))
+}
+
+func TestListEntityApiOperations(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		// service1 has API documentation
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/documentation/openapi"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, `{"spec": "{\"openapi\": \"3.0.1\", \"paths\": {\"/b\": {\"post\": {\"operationId\": \"createB\"}, \"get\": {\"summary\": \"List B\"}}, \"/a\": {\"get\": {}}}}"}`, nil),
+		),
+		// service4 has an invalid spec, so is skipped
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service4/documentation/openapi"),
+			gh.RespondWith(http.StatusOK, `{"spec": "paths: [unclosed"}`, nil),
+		),
+		// service2 falls back to its descriptor
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service2/documentation/openapi"),
+			gh.RespondWith(http.StatusNotFound, `{"details": "not found"}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service2/openapi", "yaml=false"),
+			gh.RespondWith(http.StatusOK, `{"openapi": "3.0.1", "info": {"x-cortex-tag": "service2"}, "paths": {"/health": {"get": {"operationId": "health", "tags": ["ops"]}}}}`, nil),
+		),
+		// service3 does not exist any more
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service3/documentation/openapi"),
+			gh.RespondWith(http.StatusNotFound, `{"details": "not found"}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service3/openapi"),
+			gh.RespondWith(http.StatusNotFound, `{"details": "not found"}`, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexEntityApiOperationRow](100)
+
+	err := listEntityApiOperations(ctx, client, writer, []string{"service1", "service4", "service2", "service3"})
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(Equal([]CortexEntityApiOperationRow{
+		{EntityTag: "service1", Path: "/a", Method: "GET", Source: ApiOperationSourceDocumentation},
+		{EntityTag: "service1", Path: "/b", Method: "GET", Source: ApiOperationSourceDocumentation, CortexOperation: CortexOperation{Summary: "List B"}},
+		{EntityTag: "service1", Path: "/b", Method: "POST", Source: ApiOperationSourceDocumentation, CortexOperation: CortexOperation{OperationID: "createB"}},
+		{EntityTag: "service2", Path: "/health", Method: "GET", Source: ApiOperationSourceDescriptor, CortexOperation: CortexOperation{OperationID: "health", Tags: []string{"ops"}}},
+	}))
+}
+
+func TestListEntityApiOperationsError(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/documentation/openapi"),
+			gh.RespondWith(http.StatusInternalServerError, "{\"details\": \"fake error\"}", nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexEntityApiOperationRow](100)
+
+	err := listEntityApiOperations(ctx, client, writer, []string{"service1"})
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(Equal("error from cortex API 500 Internal Server Error: {\"details\": \"fake error\"}"))
+}
+
+func TestGetApiDocumentationPathsInvalid(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/documentation/openapi"),
+			gh.RespondWith(http.StatusOK, `{"spec": "paths: [unclosed"}`, nil),
+		),
+	)
+	defer server.Close()
+
+	paths, err := getApiDocumentationPaths(ctx, client, "service1")
+	g.Expect(paths).To(BeNil())
+	g.Expect(errors.Is(err, errInvalidApiDocumentation)).To(BeTrue())
+	g.Expect(err.Error()).To(HavePrefix("invalid API documentation for service1: yaml: "))
+}
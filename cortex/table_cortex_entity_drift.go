@@ -0,0 +1,165 @@
+package cortex
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Used to represent a single field which differs between the descriptor and
+// the catalog entity in the table
+type CortexEntityDriftRow struct {
+	Tag             string
+	Field           string
+	DescriptorValue interface{}
+	EntityValue     interface{}
+}
+
+func tableCortexEntityDrift() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_entity_drift",
+		Description: "Fields which differ between the Cortex descriptor and the catalog entity.",
+		List: &plugin.ListConfig{
+			Hydrate: listEntityDriftHydrator,
+		},
+		Columns: []*plugin.Column{
+			{Name: "tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity."},
+			{Name: "field", Type: proto.ColumnType_STRING, Description: "The field which differs: title, description, groups, owner_teams, owner_individuals, parents, links or git_repository."},
+			{Name: "descriptor_value", Type: proto.ColumnType_JSON, Description: "Value of the field in the descriptor."},
+			{Name: "entity_value", Type: proto.ColumnType_JSON, Description: "Value of the field in the catalog entity."},
+		},
+	}
+}
+
+func listEntityDriftHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+	return nil, listEntityDrift(ctx, config, client, &hydratorWriter)
+}
+
+// listEntityDrift compares every descriptor with the entity of the same tag.
+// Entities without a descriptor, and descriptors without an entity, are
+// skipped.
+func listEntityDrift(ctx context.Context, config *SteampipeConfig, client *req.Client, writer HydratorWriter) error {
+	descriptors := SliceWriter[CortexInfo]{Limit: math.MaxInt64}
+	err := listConfiguredDescriptors(ctx, config, client, &descriptors, false)
+	if err != nil {
+		return err
+	}
+	byTag := make(map[string]CortexInfo, len(descriptors.Items))
+	for _, descriptor := range descriptors.Items {
+		byTag[descriptor.Tag] = descriptor
+	}
+
+	entityWriter := ExpandWriter[CortexEntityElement]{
+		Writer: writer,
+		Expand: func(entity CortexEntityElement) []interface{} {
+			descriptor, ok := byTag[entity.Tag]
+			if !ok {
+				return nil
+			}
+			var rows []interface{}
+			for _, row := range EntityDrift(descriptor, entity) {
+				rows = append(rows, row)
+			}
+			return rows
+		},
+	}
+	return listEntities(ctx, client, &entityWriter, "false", "", "")
+}
+
+// EntityDrift returns a row for each field which differs between a descriptor
+// and its entity. Lists are compared ignoring order, and empty values are nil.
+func EntityDrift(descriptor CortexInfo, entity CortexEntityElement) []CortexEntityDriftRow {
+	var parents, links, ownerTeams, ownerIndividuals, entityParents, entityLinks, entityTeams, entityIndividuals []string
+	for _, parent := range descriptor.Parents {
+		parents = append(parents, parent.Tag)
+	}
+	for _, link := range descriptor.Link {
+		links = append(links, link.Url)
+	}
+	// Group owners are compared with team tags and email owners with
+	// individuals. Slack owners, and groups from an identity provider, have no
+	// equivalent in the entity so are skipped.
+	for _, owner := range descriptor.Owners {
+		switch {
+		case owner.Type == "group" && (owner.Provider == "" || strings.EqualFold(owner.Provider, "CORTEX")):
+			ownerTeams = append(ownerTeams, owner.Name)
+		case owner.Type == "email":
+			ownerIndividuals = append(ownerIndividuals, owner.Email)
+		}
+	}
+	for _, parent := range entity.Hierarchy.Parents {
+		entityParents = append(entityParents, parent.Tag)
+	}
+	for _, link := range entity.Links {
+		entityLinks = append(entityLinks, link.Url)
+	}
+	for _, team := range entity.Owners.Teams {
+		entityTeams = append(entityTeams, team.Tag)
+	}
+	for _, individual := range entity.Owners.Individuals {
+		entityIndividuals = append(entityIndividuals, individual.Email)
+	}
+
+	fields := []struct {
+		Field           string
+		DescriptorValue interface{}
+		EntityValue     interface{}
+	}{
+		{"title", driftString(descriptor.Title), driftString(entity.Name)},
+		{"description", driftString(descriptor.Description), driftString(entity.Description)},
+		{"groups", driftStrings(descriptor.Groups), driftStrings(entity.Groups)},
+		{"owner_teams", driftStrings(ownerTeams), driftStrings(entityTeams)},
+		{"owner_individuals", driftStrings(ownerIndividuals), driftStrings(entityIndividuals)},
+		{"parents", driftStrings(parents), driftStrings(entityParents)},
+		{"links", driftStrings(links), driftStrings(entityLinks)},
+		{"git_repository", driftString(descriptor.Git.Normalize().Repository), driftString(entity.Git.Normalize().Repository)},
+	}
+
+	var rows []CortexEntityDriftRow
+	for _, field := range fields {
+		if reflect.DeepEqual(field.DescriptorValue, field.EntityValue) {
+			continue
+		}
+		rows = append(rows, CortexEntityDriftRow{
+			Tag:             descriptor.Tag,
+			Field:           field.Field,
+			DescriptorValue: field.DescriptorValue,
+			EntityValue:     field.EntityValue,
+		})
+	}
+	return rows
+}
+
+// driftString trims the value, returning nil if it is empty.
+func driftString(value string) interface{} {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// driftStrings returns a sorted copy of the values without empty strings, or
+// nil if there are none.
+func driftStrings(values []string) interface{} {
+	var sorted []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			sorted = append(sorted, value)
+		}
+	}
+	if len(sorted) == 0 {
+		return nil
+	}
+	sort.Strings(sorted)
+	return sorted
+}
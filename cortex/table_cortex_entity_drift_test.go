@@ -0,0 +1,168 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestTableCortexEntityDrift(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexEntityDrift()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_entity_drift"))
+	g.Expect(table.Description).To(Equal("Fields which differ between the Cortex descriptor and the catalog entity."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"tag", proto.ColumnType_STRING},
+		{"field", proto.ColumnType_STRING},
+		{"descriptor_value", proto.ColumnType_JSON},
+		{"entity_value", proto.ColumnType_JSON},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestEntityDrift(t *testing.T) {
+	g := NewWithT(t)
+
+	descriptor := CortexInfo{
+		Tag:         "service1",
+		Title:       "Service 1",
+		Description: "The first service ",
+		Groups:      []string{"b", "a"},
+		Owners: []CortexOwner{
+			{Type: "group", Name: "team-a", Provider: "CORTEX"},
+			{Type: "email", Email: "me@example.com"},
+			// Not compared with the entity
+			{Type: "group", Name: "okta-group", Provider: "OKTA"},
+			{Type: "slack", Channel: "team-a"},
+		},
+		Parents: []CortexTag{{Tag: "domain1"}},
+		Link:    []CortexLink{{Name: "Docs", Url: "https://example.com/docs"}},
+		Git:     CortexGit{Github: CortexGitRepository{Repository: "my-org/service1"}},
+	}
+
+	// Order and whitespace do not matter
+	entity := CortexEntityElement{
+		Tag:         "service1",
+		Name:        "Service 1",
+		Description: "The first service",
+		Groups:      []string{"a", "b"},
+		Owners: CortexEntityOwners{
+			Teams:       []CortexEntityOwnersTeam{{Tag: "team-a"}},
+			Individuals: []CortexEntityOwnersIndividual{{Email: "me@example.com"}},
+		},
+		Hierarchy: CortexEntityElementHierarchy{Parents: []CortexTag{{Tag: "domain1"}}},
+		Links:     []CortexLink{{Name: "Docs", Url: "https://example.com/docs"}},
+		Git:       CortexEntityGit{Provider: "GITHUB", Repository: "my-org/service1"},
+	}
+	g.Expect(EntityDrift(descriptor, entity)).To(BeEmpty())
+
+	entity.Name = "Service One"
+	entity.Description = ""
+	entity.Groups = []string{"a", "c"}
+	entity.Owners.Teams = append(entity.Owners.Teams, CortexEntityOwnersTeam{Tag: "team-b"})
+	entity.Owners.Individuals = nil
+	entity.Hierarchy.Parents = nil
+	entity.Links = nil
+	entity.Git.Repository = "my-org/service-one"
+
+	g.Expect(EntityDrift(descriptor, entity)).To(Equal([]CortexEntityDriftRow{
+		{Tag: "service1", Field: "title", DescriptorValue: "Service 1", EntityValue: "Service One"},
+		{Tag: "service1", Field: "description", DescriptorValue: "The first service", EntityValue: nil},
+		{Tag: "service1", Field: "groups", DescriptorValue: []string{"a", "b"}, EntityValue: []string{"a", "c"}},
+		{Tag: "service1", Field: "owner_teams", DescriptorValue: []string{"team-a"}, EntityValue: []string{"team-a", "team-b"}},
+		{Tag: "service1", Field: "owner_individuals", DescriptorValue: []string{"me@example.com"}, EntityValue: nil},
+		{Tag: "service1", Field: "parents", DescriptorValue: []string{"domain1"}, EntityValue: nil},
+		{Tag: "service1", Field: "links", DescriptorValue: []string{"https://example.com/docs"}, EntityValue: nil},
+		{Tag: "service1", Field: "git_repository", DescriptorValue: "my-org/service1", EntityValue: "my-org/service-one"},
+	}))
+}
+
+func TestEntityDriftOwners(t *testing.T) {
+	g := NewWithT(t)
+
+	// A team and an individual with the same name are not the same owner
+	descriptor := CortexInfo{
+		Tag:    "service1",
+		Owners: []CortexOwner{{Type: "group", Name: "alice@example.com"}},
+	}
+	entity := CortexEntityElement{
+		Tag:    "service1",
+		Owners: CortexEntityOwners{Individuals: []CortexEntityOwnersIndividual{{Email: "alice@example.com"}}},
+	}
+	g.Expect(EntityDrift(descriptor, entity)).To(Equal([]CortexEntityDriftRow{
+		{Tag: "service1", Field: "owner_teams", DescriptorValue: []string{"alice@example.com"}, EntityValue: nil},
+		{Tag: "service1", Field: "owner_individuals", DescriptorValue: nil, EntityValue: []string{"alice@example.com"}},
+	}))
+
+	// Slack and identity provider owners are not drift, even from an entity without owners
+	descriptor.Owners = []CortexOwner{
+		{Type: "slack", Channel: "team-a", NotificationsEnabled: true},
+		{Type: "group", Name: "Engineering", Provider: "OKTA"},
+		{Type: "group", Name: "my-org/team-a", Provider: "GITHUB"},
+	}
+	g.Expect(EntityDrift(descriptor, CortexEntityElement{Tag: "service1"})).To(BeEmpty())
+}
+
+func TestListEntityDrift(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/descriptors"),
+			gh.RespondWith(http.StatusOK, `{
+				"descriptors": [
+					{"openapi": "3.0.1", "info": {"x-cortex-tag": "service1", "title": "Service 1"}},
+					{"openapi": "3.0.1", "info": {"x-cortex-tag": "service2", "title": "Service 2"}},
+					{"openapi": "3.0.1", "info": {"x-cortex-tag": "not-imported", "title": "Not imported"}}
+				],
+				"page": 0,
+				"totalPages": 1,
+				"total": 3
+			}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog"),
+			gh.RespondWith(http.StatusOK, `{
+				"entities": [
+					{"tag": "service1", "name": "Service 1"},
+					{"tag": "service2", "name": "Renamed in the UI"},
+					{"tag": "ui-only", "name": "UI only"}
+				],
+				"page": 0,
+				"totalPages": 1,
+				"total": 3
+			}`, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexEntityDriftRow](100)
+
+	err := listEntityDrift(ctx, NewSteampipeConfig("fake_api_key", server.URL()), client, writer)
+	g.Expect(err).To(BeNil())
+	g.Expect(writer.Items).To(Equal([]CortexEntityDriftRow{
+		{Tag: "service2", Field: "title", DescriptorValue: "Service 2", EntityValue: "Renamed in the UI"},
+	}))
+}
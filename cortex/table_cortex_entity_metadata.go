@@ -0,0 +1,121 @@
+package cortex
+
+import (
+	"context"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Used to represent a single custom metadata key of an entity in the table
+type CortexEntityMetadataRow struct {
+	Tag        string
+	EntityType string
+	Key        string
+	Value      interface{}
+}
+
+// ValueType returns the JSON type of the value: string, number, boolean, array, object or null.
+func (r *CortexEntityMetadataRow) ValueType() string {
+	switch r.Value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case int, int64, uint64, float64:
+		return "number"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func (r *CortexEntityMetadataRow) StringValue() interface{} {
+	if value, ok := r.Value.(string); ok {
+		return value
+	}
+	return nil
+}
+
+func (r *CortexEntityMetadataRow) NumberValue() interface{} {
+	switch value := r.Value.(type) {
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case uint64:
+		return float64(value)
+	case float64:
+		return value
+	}
+	return nil
+}
+
+func (r *CortexEntityMetadataRow) BooleanValue() interface{} {
+	if value, ok := r.Value.(bool); ok {
+		return value
+	}
+	return nil
+}
+
+func tableCortexEntityMetadata() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_entity_metadata",
+		Description: "Cortex entity custom metadata as key/value rows.",
+		List: &plugin.ListConfig{
+			Hydrate: listEntityMetadataHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "entity_type", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity."},
+			{Name: "entity_type", Type: proto.ColumnType_STRING, Description: "Entity Type."},
+			{Name: "key", Type: proto.ColumnType_STRING, Description: "Custom metadata key."},
+			{Name: "value", Type: proto.ColumnType_JSON, Description: "Raw custom metadata value.", Transform: transform.FromField("Value")},
+			{Name: "value_type", Type: proto.ColumnType_STRING, Description: "JSON type of the value: string, number, boolean, array, object or null.", Transform: transform.FromP(transform.MethodValue, "ValueType")},
+			{Name: "string_value", Type: proto.ColumnType_STRING, Description: "Value if it is a string.", Transform: transform.FromP(transform.MethodValue, "StringValue")},
+			{Name: "number_value", Type: proto.ColumnType_DOUBLE, Description: "Value if it is a number.", Transform: transform.FromP(transform.MethodValue, "NumberValue")},
+			{Name: "boolean_value", Type: proto.ColumnType_BOOL, Description: "Value if it is a boolean.", Transform: transform.FromP(transform.MethodValue, "BooleanValue")},
+		},
+	}
+}
+
+func listEntityMetadataHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+
+	types := ""
+	if d.Quals["entity_type"] != nil {
+		types = buildListFilter(d.Quals["entity_type"].Quals)
+	}
+
+	logger.Info("listEntityMetadataHydrator", "types", types)
+	return nil, listEntityMetadata(ctx, client, &hydratorWriter, types)
+}
+
+func listEntityMetadata(ctx context.Context, client *req.Client, writer HydratorWriter, types string) error {
+	metadataWriter := ExpandWriter[CortexEntityElement]{
+		Writer: writer,
+		Expand: func(entity CortexEntityElement) []interface{} {
+			var rows []interface{}
+			for _, metadata := range entity.Metadata {
+				rows = append(rows, CortexEntityMetadataRow{
+					Tag:        entity.Tag,
+					EntityType: entity.Type,
+					Key:        metadata.Key,
+					Value:      metadata.Value.Value(),
+				})
+			}
+			return rows
+		},
+	}
+	return listEntities(ctx, client, &metadataWriter, "false", types, "")
+}
@@ -0,0 +1,138 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"gopkg.in/yaml.v3"
+)
+
+const entityMetadataResponse = `{
+  "entities": [
+    {
+      "tag": "service1",
+      "type": "service",
+      "metadata": [
+        {"key": "tier", "value": "gold"},
+        {"key": "cost", "value": 12.5},
+        {"key": "public", "value": true},
+        {"key": "languages", "value": ["go", "python"]},
+        {"key": "contacts", "value": [{"name": "alice", "roles": ["dev"]}]},
+        {"key": "limits", "value": {"cpu": 2, "nested": {"memory": "1Gi"}}},
+        {"key": "empty", "value": null}
+      ]
+    },
+    {"tag": "service2", "type": "service"}
+  ],
+  "page": 0,
+  "totalPages": 1,
+  "total": 2
+}`
+
+func TestTableCortexEntityMetadata(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexEntityMetadata()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_entity_metadata"))
+	g.Expect(table.Description).To(Equal("Cortex entity custom metadata as key/value rows."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(1))
+	g.Expect(table.List.KeyColumns[0].Name).To(Equal("entity_type"))
+	g.Expect(table.List.KeyColumns[0].Require).To(Equal(plugin.Optional))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"tag", proto.ColumnType_STRING},
+		{"entity_type", proto.ColumnType_STRING},
+		{"key", proto.ColumnType_STRING},
+		{"value", proto.ColumnType_JSON},
+		{"value_type", proto.ColumnType_STRING},
+		{"string_value", proto.ColumnType_STRING},
+		{"number_value", proto.ColumnType_DOUBLE},
+		{"boolean_value", proto.ColumnType_BOOL},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestMetadataValueUnmarshal(t *testing.T) {
+	g := NewWithT(t)
+
+	var response CortexEntityResponse
+	err := yaml.Unmarshal([]byte(entityMetadataResponse), &response)
+	g.Expect(err).To(BeNil())
+
+	metadata := response.Entities[0].Metadata
+	g.Expect(metadata).To(HaveLen(7))
+	g.Expect(metadata[0].Value.Value()).To(Equal("gold"))
+	g.Expect(metadata[1].Value.Value()).To(Equal(12.5))
+	g.Expect(metadata[2].Value.Value()).To(Equal(true))
+	g.Expect(metadata[3].Value.Value()).To(Equal([]interface{}{"go", "python"}))
+	g.Expect(metadata[4].Value.Value()).To(Equal([]interface{}{
+		map[string]interface{}{"name": "alice", "roles": []interface{}{"dev"}},
+	}))
+	g.Expect(metadata[5].Value.Value()).To(Equal(map[string]interface{}{
+		"cpu":    2,
+		"nested": map[string]interface{}{"memory": "1Gi"},
+	}))
+	g.Expect(metadata[6].Value.Value()).To(BeNil())
+
+	// Values survive a round trip through marshalling.
+	out, err := yaml.Marshal(response)
+	g.Expect(err).To(BeNil())
+	var roundTrip CortexEntityResponse
+	g.Expect(yaml.Unmarshal(out, &roundTrip)).To(Succeed())
+	g.Expect(roundTrip.Entities[0].Metadata).To(Equal(metadata))
+}
+
+func TestListEntityMetadata(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, entityMetadataResponse, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexEntityMetadataRow](100)
+
+	err := listEntityMetadata(ctx, client, writer, "service")
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(HaveLen(7))
+	g.Expect(writer.Items[0].Tag).To(Equal("service1"))
+	g.Expect(writer.Items[0].EntityType).To(Equal("service"))
+
+	valueTypes := []string{}
+	for _, row := range writer.Items {
+		valueTypes = append(valueTypes, row.ValueType())
+	}
+	g.Expect(valueTypes).To(Equal([]string{"string", "number", "boolean", "array", "array", "object", "null"}))
+
+	g.Expect(writer.Items[0].StringValue()).To(Equal("gold"))
+	g.Expect(writer.Items[0].NumberValue()).To(BeNil())
+	g.Expect(writer.Items[1].NumberValue()).To(Equal(12.5))
+	g.Expect(writer.Items[2].BooleanValue()).To(Equal(true))
+	g.Expect(writer.Items[3].StringValue()).To(BeNil())
+}
@@ -0,0 +1,151 @@
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+type CortexEntityRelationshipResponse struct {
+	Sources      []CortexRelatedEntity `yaml:"sources"`
+	Destinations []CortexRelatedEntity `yaml:"destinations"`
+}
+
+type CortexRelatedEntity struct {
+	Tag         string `yaml:"tag"`
+	Name        string `yaml:"name"`
+	Type        string `yaml:"type"`
+	Description string `yaml:"description"`
+}
+
+// Used to represent a single source to destination edge in the table
+type CortexEntityRelationshipRow struct {
+	RelationshipType string
+	SourceTag        string
+	DestinationTag   string
+}
+
+func tableCortexEntityRelationship() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_entity_relationship",
+		Description: "Cortex custom relationships between entities.",
+		List: &plugin.ListConfig{
+			Hydrate: listEntityRelationshipsHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "relationship_type", Require: plugin.Optional},
+				{Name: "source_tag", Require: plugin.Optional},
+				{Name: "destination_tag", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "relationship_type", Type: proto.ColumnType_STRING, Description: "Tag of the relationship type."},
+			{Name: "source_tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the source entity."},
+			{Name: "destination_tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the destination entity."},
+		},
+	}
+}
+
+func listEntityRelationshipsHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+
+	var relationshipTypes []string
+	var err error
+	if d.EqualsQuals["relationship_type"] != nil {
+		relationshipTypes = []string{d.EqualsQuals["relationship_type"].GetStringValue()}
+	} else {
+		relationshipTypes, err = listRelationshipTypeTags(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Query the smallest part of the graph we can: destinations of the
+	// source, sources of the destination, or destinations of every entity.
+	incoming := false
+	var tags []string
+	if d.EqualsQuals["source_tag"] != nil {
+		tags = []string{d.EqualsQuals["source_tag"].GetStringValue()}
+	} else if d.EqualsQuals["destination_tag"] != nil {
+		tags = []string{d.EqualsQuals["destination_tag"].GetStringValue()}
+		incoming = true
+	} else {
+		tags, err = listEntityTags(ctx, client, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Info("listEntityRelationshipsHydrator", "relationshipTypes", relationshipTypes, "tags", len(tags), "incoming", incoming)
+	return nil, listEntityRelationships(ctx, client, &hydratorWriter, relationshipTypes, tags, incoming)
+}
+
+// listEntityRelationships streams the destinations of each of the tags, or
+// the sources of each of the tags if incoming is set, for every relationship type.
+func listEntityRelationships(ctx context.Context, client *req.Client, writer HydratorWriter, relationshipTypes []string, tags []string, incoming bool) error {
+	logger := plugin.Logger(ctx)
+
+	direction := "destinations"
+	if incoming {
+		direction = "sources"
+	}
+
+	for _, relationshipType := range relationshipTypes {
+		for _, tag := range tags {
+			logger.Debug("listEntityRelationships", "relationshipType", relationshipType, "tag", tag, "direction", direction)
+			resp := client.
+				Get("/api/v1/catalog/{tag}/relationships/{relationshipType}/{direction}").
+				SetPathParam("tag", tag).
+				SetPathParam("relationshipType", relationshipType).
+				SetPathParam("direction", direction).
+				Do(ctx)
+
+			// Entities outside of the relationship type's filters have no relationships
+			if resp.GetStatusCode() == http.StatusNotFound {
+				continue
+			}
+
+			// Check for HTTP errors
+			if resp.IsErrorState() {
+				logger.Error("listEntityRelationships", "relationshipType", relationshipType, "tag", tag, "Status", resp.Status, "Body", resp.String())
+				return fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+			}
+
+			// Unmarshal the response and check for unmarshal errors
+			var response CortexEntityRelationshipResponse
+			err := resp.Into(&response)
+			if err != nil {
+				logger.Error("listEntityRelationships", "relationshipType", relationshipType, "tag", tag, "Error", err)
+				return err
+			}
+
+			related := response.Destinations
+			if incoming {
+				related = response.Sources
+			}
+			for _, entity := range related {
+				row := CortexEntityRelationshipRow{
+					RelationshipType: relationshipType,
+					SourceTag:        tag,
+					DestinationTag:   entity.Tag,
+				}
+				if incoming {
+					row.SourceTag, row.DestinationTag = entity.Tag, tag
+				}
+				// send the item to steampipe
+				writer.StreamListItem(ctx, row)
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if writer.RowsRemaining(ctx) == 0 {
+					return nil
+				}
+			}
+		}
+	}
+	return nil
+}
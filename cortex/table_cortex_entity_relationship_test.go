@@ -0,0 +1,124 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func TestTableCortexEntityRelationship(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexEntityRelationship()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_entity_relationship"))
+	g.Expect(table.Description).To(Equal("Cortex custom relationships between entities."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(3))
+	for _, keyColumn := range table.List.KeyColumns {
+		g.Expect(keyColumn.Require).To(Equal(plugin.Optional))
+	}
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"relationship_type", proto.ColumnType_STRING},
+		{"source_tag", proto.ColumnType_STRING},
+		{"destination_tag", proto.ColumnType_STRING},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListEntityRelationshipsDestinations(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/relationships/runs-on/destinations"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, `{"destinations": [{"tag": "cluster1", "type": "cluster"}, {"tag": "cluster2", "type": "cluster"}]}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service2/relationships/runs-on/destinations"),
+			gh.RespondWith(http.StatusNotFound, `{"details": "not found"}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/relationships/consumes-topic/destinations"),
+			gh.RespondWith(http.StatusOK, `{"destinations": [{"tag": "orders"}]}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service2/relationships/consumes-topic/destinations"),
+			gh.RespondWith(http.StatusOK, `{"destinations": []}`, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexEntityRelationshipRow](100)
+
+	err := listEntityRelationships(ctx, client, writer, []string{"runs-on", "consumes-topic"}, []string{"service1", "service2"}, false)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(Equal([]CortexEntityRelationshipRow{
+		{RelationshipType: "runs-on", SourceTag: "service1", DestinationTag: "cluster1"},
+		{RelationshipType: "runs-on", SourceTag: "service1", DestinationTag: "cluster2"},
+		{RelationshipType: "consumes-topic", SourceTag: "service1", DestinationTag: "orders"},
+	}))
+}
+
+func TestListEntityRelationshipsSources(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/cluster1/relationships/runs-on/sources"),
+			gh.RespondWith(http.StatusOK, `{"sources": [{"tag": "service1"}]}`, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexEntityRelationshipRow](100)
+
+	err := listEntityRelationships(ctx, client, writer, []string{"runs-on"}, []string{"cluster1"}, true)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(Equal([]CortexEntityRelationshipRow{
+		{RelationshipType: "runs-on", SourceTag: "service1", DestinationTag: "cluster1"},
+	}))
+}
+
+func TestListEntityRelationshipsError(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/relationships/runs-on/destinations"),
+			gh.RespondWith(http.StatusInternalServerError, "{\"details\": \"fake error\"}", nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexEntityRelationshipRow](100)
+
+	err := listEntityRelationships(ctx, client, writer, []string{"runs-on"}, []string{"service1"}, false)
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(Equal("error from cortex API 500 Internal Server Error: {\"details\": \"fake error\"}"))
+}
@@ -0,0 +1,94 @@
+package cortex
+
+import (
+	"context"
+	"math"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Used to represent a single schema violation of an entity in the table
+type CortexEntitySchemaViolationRow struct {
+	Tag        string
+	EntityType string
+	SchemaViolation
+}
+
+func tableCortexEntitySchemaViolation() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_entity_schema_violation",
+		Description: "Cortex entity custom metadata which does not match the JSON schema of its entity type.",
+		List: &plugin.ListConfig{
+			Hydrate: listEntitySchemaViolationsHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "entity_type", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity."},
+			{Name: "entity_type", Type: proto.ColumnType_STRING, Description: "Entity Type."},
+			{Name: "pointer", Type: proto.ColumnType_STRING, Description: "JSON pointer to the failing value in the custom metadata, empty for the metadata itself."},
+			{Name: "keyword", Type: proto.ColumnType_STRING, Description: "JSON schema keyword which failed, e.g. type, required or enum."},
+			{Name: "expected", Type: proto.ColumnType_STRING, Description: "What the schema expected, e.g. the type of the value."},
+			{Name: "message", Type: proto.ColumnType_STRING, Description: "Description of the violation."},
+		},
+	}
+}
+
+func listEntitySchemaViolationsHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+
+	types := ""
+	if d.Quals["entity_type"] != nil {
+		types = buildListFilter(d.Quals["entity_type"].Quals)
+	}
+
+	logger.Info("listEntitySchemaViolationsHydrator", "types", types)
+	return nil, listEntitySchemaViolations(ctx, client, &hydratorWriter, types)
+}
+
+func listEntitySchemaViolations(ctx context.Context, client *req.Client, writer HydratorWriter, types string) error {
+	logger := plugin.Logger(ctx)
+
+	entityTypes := SliceWriter[CortexEntityType]{Limit: math.MaxInt64}
+	err := listEntityTypes(ctx, client, &entityTypes)
+	if err != nil {
+		return err
+	}
+	schemas := make(map[string]map[string]interface{})
+	for _, entityType := range entityTypes.Items {
+		if len(entityType.Schema) > 0 {
+			schemas[entityType.Type] = entityType.Schema
+		}
+	}
+	logger.Debug("listEntitySchemaViolations", "schemas", len(schemas))
+
+	violationWriter := ExpandWriter[CortexEntityElement]{
+		Writer: writer,
+		Expand: func(entity CortexEntityElement) []interface{} {
+			schema, ok := schemas[entity.Type]
+			if !ok {
+				return nil
+			}
+			metadata := make(map[string]interface{}, len(entity.Metadata))
+			for _, item := range entity.Metadata {
+				metadata[item.Key] = item.Value.Value()
+			}
+			var rows []interface{}
+			for _, violation := range ValidateSchema(schema, metadata) {
+				rows = append(rows, CortexEntitySchemaViolationRow{
+					Tag:             entity.Tag,
+					EntityType:      entity.Type,
+					SchemaViolation: violation,
+				})
+			}
+			return rows
+		},
+	}
+	return listEntities(ctx, client, &violationWriter, "false", types, "")
+}
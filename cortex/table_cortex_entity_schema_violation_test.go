@@ -0,0 +1,93 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func TestTableCortexEntitySchemaViolation(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexEntitySchemaViolation()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_entity_schema_violation"))
+	g.Expect(table.Description).To(Equal("Cortex entity custom metadata which does not match the JSON schema of its entity type."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(1))
+	g.Expect(table.List.KeyColumns[0].Name).To(Equal("entity_type"))
+	g.Expect(table.List.KeyColumns[0].Require).To(Equal(plugin.Optional))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"tag", proto.ColumnType_STRING},
+		{"entity_type", proto.ColumnType_STRING},
+		{"pointer", proto.ColumnType_STRING},
+		{"keyword", proto.ColumnType_STRING},
+		{"expected", proto.ColumnType_STRING},
+		{"message", proto.ColumnType_STRING},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListEntitySchemaViolations(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/definitions"),
+			gh.RespondWith(http.StatusOK, `{
+				"definitions": [
+					{"type": "service", "source": "BUILT_IN"},
+					{"type": "queue", "source": "CUSTOM", "schema": {"type": "object", "required": ["cost_center"], "properties": {"cost_center": {"type": "string"}, "partitions": {"type": "integer"}}}}
+				],
+				"page": 0,
+				"totalPages": 1,
+				"total": 2
+			}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, `{
+				"entities": [
+					{"tag": "service1", "type": "service", "metadata": [{"key": "anything", "value": 1}]},
+					{"tag": "queue1", "type": "queue", "metadata": [{"key": "cost_center", "value": "CC-1"}, {"key": "partitions", "value": 3}]},
+					{"tag": "queue2", "type": "queue", "metadata": [{"key": "partitions", "value": "3"}]}
+				],
+				"page": 0,
+				"totalPages": 1,
+				"total": 3
+			}`, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexEntitySchemaViolationRow](100)
+
+	err := listEntitySchemaViolations(ctx, client, writer, "")
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(Equal([]CortexEntitySchemaViolationRow{
+		{Tag: "queue2", EntityType: "queue", SchemaViolation: SchemaViolation{Pointer: "/cost_center", Keyword: "required", Expected: "string", Message: `required property "cost_center" is missing`}},
+		{Tag: "queue2", EntityType: "queue", SchemaViolation: SchemaViolation{Pointer: "/partitions", Keyword: "type", Expected: "integer", Message: "expected integer but got string"}},
+	}))
+}
@@ -225,7 +225,11 @@ func TestTableCortexEntity(t *testing.T) {
 		{"last_updated", proto.ColumnType_TIMESTAMP},
 		{"links", proto.ColumnType_JSON},
 		{"archived", proto.ColumnType_BOOL},
+		{"git_provider", proto.ColumnType_STRING},
 		{"repository", proto.ColumnType_STRING},
+		{"base_path", proto.ColumnType_STRING},
+		{"alias", proto.ColumnType_STRING},
+		{"repository_url", proto.ColumnType_STRING},
 		{"slack_channels", proto.ColumnType_JSON},
 		{"owner_teams", proto.ColumnType_JSON},
 		{"owner_individuals", proto.ColumnType_JSON},
@@ -264,3 +268,36 @@ func listGroupQual(operator string, values ...string) *quals.Qual {
 		},
 	}
 }
+
+func TestCortexEntityGitNormalize(t *testing.T) {
+	g := NewWithT(t)
+
+	// The URL is computed when missing from the API response.
+	git := CortexEntityGit{Provider: "GITLAB", Repository: "group/repo"}
+	g.Expect(git.Normalize()).To(Equal(CortexEntityGit{Provider: "gitlab", Repository: "group/repo", RepositoryURL: "https://gitlab.com/group/repo"}))
+
+	// The URL from the API response is preserved, e.g. for self hosted instances.
+	git = CortexEntityGit{Provider: "AZURE_DEVOPS", Repository: "proj/repo", RepositoryURL: "https://dev.azure.com/org/proj/_git/repo"}
+	g.Expect(git.Normalize()).To(Equal(CortexEntityGit{Provider: "azure", Repository: "proj/repo", RepositoryURL: "https://dev.azure.com/org/proj/_git/repo"}))
+	git = CortexEntityGit{Provider: "GITHUB", Repository: "org/repo", RepositoryURL: "https://github.example.com/org/repo"}
+	g.Expect(git.Normalize().RepositoryURL).To(Equal("https://github.example.com/org/repo"))
+}
+
+func TestListEntityTags(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	responseBytes := prepareEntityResponse(t, []CortexEntityElement{{Tag: "service1"}, {Tag: "service2"}}, 0, 1, 2)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog"),
+			gh.RespondWith(http.StatusOK, responseBytes, nil),
+		),
+	)
+	defer server.Close()
+
+	tags, err := listEntityTags(ctx, client, "")
+	g.Expect(err).To(BeNil())
+	g.Expect(tags).To(Equal([]string{"service1", "service2"}))
+}
@@ -0,0 +1,142 @@
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type CortexEntityTypeResponse struct {
+	Definitions []CortexEntityType `yaml:"definitions"`
+	Page        int                `yaml:"page"`
+	TotalPages  int                `yaml:"totalPages"`
+	Total       int                `yaml:"total"`
+}
+
+type CortexEntityType struct {
+	Type        string                 `yaml:"type"`
+	Name        string                 `yaml:"name"`
+	Description string                 `yaml:"description"`
+	Source      string                 `yaml:"source"`
+	IconTag     string                 `yaml:"iconTag"`
+	Schema      map[string]interface{} `yaml:"schema"`
+}
+
+func tableCortexEntityType() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_entity_type",
+		Description: "Cortex entity type definitions api.",
+		List: &plugin.ListConfig{
+			Hydrate: listEntityTypesHydrator,
+		},
+		Get: &plugin.GetConfig{
+			Hydrate:    getEntityTypeHydrator,
+			KeyColumns: plugin.SingleColumn("type"),
+		},
+		Columns: []*plugin.Column{
+			{Name: "type", Type: proto.ColumnType_STRING, Description: "Entity Type, as used in the type column of cortex_entity."},
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "Pretty name of the entity type."},
+			{Name: "description", Type: proto.ColumnType_STRING, Description: "Description."},
+			{Name: "source", Type: proto.ColumnType_STRING, Description: "Whether the type is BUILT_IN or CUSTOM."},
+			{Name: "icon_tag", Type: proto.ColumnType_STRING, Description: "Icon of the entity type."},
+			{Name: "schema", Type: proto.ColumnType_JSON, Description: "JSON schema of the custom attributes of the entity type.", Transform: transform.FromField("Schema")},
+		},
+	}
+}
+
+func listEntityTypesHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+	return nil, listEntityTypes(ctx, client, &hydratorWriter)
+}
+
+func listEntityTypes(ctx context.Context, client *req.Client, writer HydratorWriter) error {
+	logger := plugin.Logger(ctx)
+
+	var response CortexEntityTypeResponse
+	var page int = 0
+	for {
+		logger.Debug("listEntityTypes", "page", page)
+		resp := client.
+			Get("/api/v1/catalog/definitions").
+			// Options
+			SetQueryParam("includeBuiltIn", "true").
+			// Pagination
+			SetQueryParam("pageSize", "1000").
+			SetQueryParam("page", strconv.Itoa(page)).
+			Do(ctx)
+
+		// Check for HTTP errors
+		if resp.IsErrorState() {
+			logger.Error("listEntityTypes", "Status", resp.Status, "Body", resp.String())
+			return fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+		}
+
+		// Unmarshal the response and check for unmarshal errors
+		err := resp.Into(&response)
+		if err != nil {
+			logger.Error("listEntityTypes", "page", page, "Error", err)
+			return err
+		}
+
+		for _, result := range response.Definitions {
+			// send the item to steampipe
+			writer.StreamListItem(ctx, result)
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if writer.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+		page++
+		if page >= response.TotalPages {
+			break
+		}
+	}
+	return nil
+}
+
+func getEntityTypeHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	entityType := d.EqualsQuals["type"].GetStringValue()
+	logger.Info("getEntityTypeHydrator", "type", entityType)
+	return getEntityType(ctx, client, entityType)
+}
+
+// getEntityType returns a single entity type definition, or nil if it does not exist.
+func getEntityType(ctx context.Context, client *req.Client, entityType string) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+
+	resp := client.
+		Get("/api/v1/catalog/definitions/{type}").
+		SetPathParam("type", entityType).
+		Do(ctx)
+
+	// A missing entity type is not an error, there is just no row
+	if resp.GetStatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	// Check for HTTP errors
+	if resp.IsErrorState() {
+		logger.Error("getEntityType", "type", entityType, "Status", resp.Status, "Body", resp.String())
+		return nil, fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+	}
+
+	// Unmarshal the response and check for unmarshal errors
+	var response CortexEntityType
+	err := resp.Into(&response)
+	if err != nil {
+		logger.Error("getEntityType", "type", entityType, "Error", err)
+		return nil, err
+	}
+	return response, nil
+}
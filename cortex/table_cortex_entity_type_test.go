@@ -0,0 +1,151 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"gopkg.in/yaml.v3"
+)
+
+func prepareEntityTypeResponse(t *testing.T, definitions []CortexEntityType, page, totalPages, total int) []byte {
+	t.Helper()
+	response := CortexEntityTypeResponse{
+		Definitions: definitions,
+		Page:        page,
+		TotalPages:  totalPages,
+		Total:       total,
+	}
+	responseBytes, err := yaml.Marshal(response)
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	return responseBytes
+}
+
+func TestTableCortexEntityType(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexEntityType()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_entity_type"))
+	g.Expect(table.Description).To(Equal("Cortex entity type definitions api."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+
+	// Check get configuration.
+	g.Expect(table.Get).ToNot(BeNil())
+	g.Expect(table.Get.Hydrate).ToNot(BeNil())
+	g.Expect(table.Get.KeyColumns).To(HaveLen(1))
+	g.Expect(table.Get.KeyColumns[0].Name).To(Equal("type"))
+	g.Expect(table.Get.KeyColumns[0].Require).To(Equal(plugin.Required))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"type", proto.ColumnType_STRING},
+		{"name", proto.ColumnType_STRING},
+		{"description", proto.ColumnType_STRING},
+		{"source", proto.ColumnType_STRING},
+		{"icon_tag", proto.ColumnType_STRING},
+		{"schema", proto.ColumnType_JSON},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListEntityTypesMultiPage(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	respPage0Bytes := prepareEntityTypeResponse(t, []CortexEntityType{
+		{Type: "service", Name: "Service", Source: "BUILT_IN"},
+	}, 0, 2, 2)
+	respPage1Bytes := prepareEntityTypeResponse(t, []CortexEntityType{
+		{Type: "queue", Name: "Queue", Source: "CUSTOM", Schema: map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"cost_center"},
+		}},
+	}, 1, 2, 2)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/definitions", "includeBuiltIn=true&page=0&pageSize=1000"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, respPage0Bytes, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/definitions", "includeBuiltIn=true&page=1&pageSize=1000"),
+			gh.RespondWith(http.StatusOK, respPage1Bytes, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexEntityType](100)
+
+	err := listEntityTypes(ctx, client, writer)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(HaveLen(2))
+	g.Expect(writer.Items[0].Type).To(Equal("service"))
+	g.Expect(writer.Items[1].Source).To(Equal("CUSTOM"))
+	g.Expect(writer.Items[1].Schema).To(HaveKeyWithValue("required", []interface{}{"cost_center"}))
+}
+
+func TestListEntityTypesError(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/definitions"),
+			gh.RespondWith(http.StatusInternalServerError, "{\"details\": \"fake error on page 0\"}", nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexEntityType](100)
+
+	err := listEntityTypes(ctx, client, writer)
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(Equal("error from cortex API 500 Internal Server Error: {\"details\": \"fake error on page 0\"}"))
+}
+
+func TestGetEntityType(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/definitions/queue"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, `{"type": "queue", "name": "Queue", "source": "CUSTOM", "schema": {"type": "object"}}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/definitions/missing"),
+			gh.RespondWith(http.StatusNotFound, `{"details": "not found"}`, nil),
+		),
+	)
+	defer server.Close()
+
+	item, err := getEntityType(ctx, client, "queue")
+	g.Expect(err).To(BeNil())
+	g.Expect(item).To(Equal(CortexEntityType{Type: "queue", Name: "Queue", Source: "CUSTOM", Schema: map[string]interface{}{"type": "object"}}))
+
+	item, err = getEntityType(ctx, client, "missing")
+	g.Expect(err).To(BeNil())
+	g.Expect(item).To(BeNil())
+}
@@ -0,0 +1,169 @@
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	GitopsStatusSuccess = "SUCCESS"
+	GitopsStatusFailure = "FAILURE"
+)
+
+type CortexGitopsLogResponse struct {
+	Logs       []CortexGitopsLog `yaml:"logs"`
+	Page       int               `yaml:"page"`
+	TotalPages int               `yaml:"totalPages"`
+	Total      int               `yaml:"total"`
+}
+
+type CortexGitopsLog struct {
+	Commit      string                 `yaml:"commit"`
+	DateCreated string                 `yaml:"dateCreated"`
+	Repository  CortexGitopsRepository `yaml:"repository"`
+	Files       []CortexGitopsFile     `yaml:"files"`
+}
+
+type CortexGitopsRepository struct {
+	Provider       string `yaml:"provider"`
+	RepositoryName string `yaml:"repositoryName"`
+}
+
+type CortexGitopsFile struct {
+	FileName  string              `yaml:"fileName"`
+	FileType  string              `yaml:"fileType"`
+	EntityTag string              `yaml:"entityTag"`
+	Operation string              `yaml:"operation"`
+	Errors    []CortexGitopsError `yaml:"errors"`
+}
+
+// CortexGitopsError is an error message, which the API returns either as a
+// plain string or as an object with a message field.
+type CortexGitopsError struct {
+	Message string `yaml:"message"`
+}
+
+func (e *CortexGitopsError) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		e.Message = value.Value
+		return nil
+	}
+	type plain CortexGitopsError
+	return value.Decode((*plain)(e))
+}
+
+// Used to represent a single processed file of a GitOps log in the table
+type CortexGitopsLogRow struct {
+	Provider   string
+	Repository string
+	CommitSha  string
+	Timestamp  string
+	FilePath   string
+	FileType   string
+	EntityTag  string
+	Operation  string
+	Errors     []string
+}
+
+// Status is FAILURE if processing the file returned any errors, SUCCESS otherwise.
+func (r *CortexGitopsLogRow) Status() string {
+	if len(r.Errors) > 0 {
+		return GitopsStatusFailure
+	}
+	return GitopsStatusSuccess
+}
+
+func tableCortexGitopsLog() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_gitops_log",
+		Description: "Cortex GitOps logs api, one row per processed file.",
+		List: &plugin.ListConfig{
+			Hydrate: listGitopsLogsHydrator,
+		},
+		Columns: []*plugin.Column{
+			{Name: "timestamp", Type: proto.ColumnType_TIMESTAMP, Description: "Time the commit was processed."},
+			{Name: "provider", Type: proto.ColumnType_STRING, Description: "Git provider of the repository."},
+			{Name: "repository", Type: proto.ColumnType_STRING, Description: "Repository name."},
+			{Name: "commit_sha", Type: proto.ColumnType_STRING, Description: "SHA of the processed commit."},
+			{Name: "file_path", Type: proto.ColumnType_STRING, Description: "Path of the file in the repository."},
+			{Name: "file_type", Type: proto.ColumnType_STRING, Description: "Type of the file, e.g. ENTITY or SCORECARD."},
+			{Name: "entity_tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity defined by the file."},
+			{Name: "operation", Type: proto.ColumnType_STRING, Description: "What Cortex did with the file, e.g. CREATE, UPDATE or ARCHIVE."},
+			{Name: "status", Type: proto.ColumnType_STRING, Description: "SUCCESS, or FAILURE if there were errors.", Transform: transform.FromP(transform.MethodValue, "Status")},
+			{Name: "errors", Type: proto.ColumnType_JSON, Description: "Error messages from processing the file.", Transform: transform.FromField("Errors")},
+		},
+	}
+}
+
+func listGitopsLogsHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+	return nil, listGitopsLogs(ctx, client, &hydratorWriter)
+}
+
+func listGitopsLogs(ctx context.Context, client *req.Client, writer HydratorWriter) error {
+	logger := plugin.Logger(ctx)
+
+	var response CortexGitopsLogResponse
+	var page int = 0
+	for {
+		logger.Debug("listGitopsLogs", "page", page)
+		resp := client.
+			Get("/api/v1/gitops-logs").
+			// Pagination
+			SetQueryParam("pageSize", "1000").
+			SetQueryParam("page", strconv.Itoa(page)).
+			Do(ctx)
+
+		// Check for HTTP errors
+		if resp.IsErrorState() {
+			logger.Error("listGitopsLogs", "Status", resp.Status, "Body", resp.String())
+			return fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+		}
+
+		// Unmarshal the response and check for unmarshal errors
+		err := resp.Into(&response)
+		if err != nil {
+			logger.Error("listGitopsLogs", "page", page, "Error", err)
+			return err
+		}
+
+		for _, log := range response.Logs {
+			for _, file := range log.Files {
+				var errors []string
+				for _, fileError := range file.Errors {
+					errors = append(errors, fileError.Message)
+				}
+				// send the item to steampipe
+				writer.StreamListItem(ctx, CortexGitopsLogRow{
+					Provider:   log.Repository.Provider,
+					Repository: log.Repository.RepositoryName,
+					CommitSha:  log.Commit,
+					Timestamp:  log.DateCreated,
+					FilePath:   file.FileName,
+					FileType:   file.FileType,
+					EntityTag:  file.EntityTag,
+					Operation:  file.Operation,
+					Errors:     errors,
+				})
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if writer.RowsRemaining(ctx) == 0 {
+					return nil
+				}
+			}
+		}
+		page++
+		if page >= response.TotalPages {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestTableCortexGitopsLog(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexGitopsLog()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_gitops_log"))
+	g.Expect(table.Description).To(Equal("Cortex GitOps logs api, one row per processed file."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"timestamp", proto.ColumnType_TIMESTAMP},
+		{"provider", proto.ColumnType_STRING},
+		{"repository", proto.ColumnType_STRING},
+		{"commit_sha", proto.ColumnType_STRING},
+		{"file_path", proto.ColumnType_STRING},
+		{"file_type", proto.ColumnType_STRING},
+		{"entity_tag", proto.ColumnType_STRING},
+		{"operation", proto.ColumnType_STRING},
+		{"status", proto.ColumnType_STRING},
+		{"errors", proto.ColumnType_JSON},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListGitopsLogsMultiPage(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/gitops-logs", "page=0&pageSize=1000"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, `{
+				"logs": [
+					{
+						"commit": "abc123",
+						"dateCreated": "2025-01-01T00:00:00Z",
+						"repository": {"provider": "GITHUB", "repositoryName": "org/service1"},
+						"files": [
+							{"fileName": "cortex.yaml", "fileType": "ENTITY", "entityTag": "service1", "operation": "UPDATE"},
+							{"fileName": "sub/cortex.yaml", "fileType": "ENTITY", "errors": ["x-cortex-owners: team-x does not exist", {"message": "invalid link"}]}
+						]
+					}
+				],
+				"page": 0,
+				"totalPages": 2,
+				"total": 2
+			}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/gitops-logs", "page=1&pageSize=1000"),
+			gh.RespondWith(http.StatusOK, `{
+				"logs": [
+					{"commit": "def456", "repository": {"provider": "GITLAB", "repositoryName": "org/service2"}, "files": []}
+				],
+				"page": 1,
+				"totalPages": 2,
+				"total": 2
+			}`, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexGitopsLogRow](100)
+
+	err := listGitopsLogs(ctx, client, writer)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(HaveLen(2))
+	g.Expect(writer.Items[0]).To(Equal(CortexGitopsLogRow{
+		Provider:   "GITHUB",
+		Repository: "org/service1",
+		CommitSha:  "abc123",
+		Timestamp:  "2025-01-01T00:00:00Z",
+		FilePath:   "cortex.yaml",
+		FileType:   "ENTITY",
+		EntityTag:  "service1",
+		Operation:  "UPDATE",
+	}))
+	g.Expect(writer.Items[0].Status()).To(Equal(GitopsStatusSuccess))
+	g.Expect(writer.Items[1].FilePath).To(Equal("sub/cortex.yaml"))
+	g.Expect(writer.Items[1].Errors).To(Equal([]string{"x-cortex-owners: team-x does not exist", "invalid link"}))
+	g.Expect(writer.Items[1].Status()).To(Equal(GitopsStatusFailure))
+}
+
+func TestListGitopsLogsError(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/gitops-logs"),
+			gh.RespondWith(http.StatusInternalServerError, "{\"details\": \"fake error on page 0\"}", nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexGitopsLogRow](100)
+
+	err := listGitopsLogs(ctx, client, writer)
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(Equal("error from cortex API 500 Internal Server Error: {\"details\": \"fake error on page 0\"}"))
+}
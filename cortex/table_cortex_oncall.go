@@ -0,0 +1,245 @@
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"gopkg.in/yaml.v3"
+)
+
+// Response elements for the /catalog/{tag}/integrations/oncall/current
+// endpoint. The shape of each escalation depends on the source provider.
+type CortexOncallCurrentResponse struct {
+	Source      string    `yaml:"source"`
+	Escalations yaml.Node `yaml:"escalations"`
+}
+
+// PagerDuty on-call, as returned by the PagerDuty oncalls API
+type CortexOncallPagerDuty struct {
+	EscalationPolicy CortexOncallPagerDutyReference `yaml:"escalation_policy"`
+	EscalationLevel  int                            `yaml:"escalation_level"`
+	Schedule         CortexOncallPagerDutyReference `yaml:"schedule"`
+	User             CortexOncallPagerDutyReference `yaml:"user"`
+	Start            string                         `yaml:"start"`
+	End              string                         `yaml:"end"`
+}
+
+type CortexOncallPagerDutyReference struct {
+	Summary string `yaml:"summary"`
+	Name    string `yaml:"name"`
+	Email   string `yaml:"email"`
+}
+
+// OpsGenie on-call, as returned by the OpsGenie who-is-on-call API
+type CortexOncallOpsGenie struct {
+	Parent       CortexOncallOpsGenieParent        `yaml:"_parent"`
+	Participants []CortexOncallOpsGenieParticipant `yaml:"onCallParticipants"`
+}
+
+type CortexOncallOpsGenieParent struct {
+	Name string `yaml:"name"`
+}
+
+type CortexOncallOpsGenieParticipant struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+}
+
+// VictorOps on-call, as returned by the VictorOps current on-call API
+type CortexOncallVictorOpsTeam struct {
+	Team      CortexOncallVictorOpsReference `yaml:"team"`
+	OncallNow []CortexOncallVictorOpsNow     `yaml:"oncallNow"`
+}
+
+type CortexOncallVictorOpsNow struct {
+	EscalationPolicy CortexOncallVictorOpsReference `yaml:"escalationPolicy"`
+	Users            []CortexOncallVictorOpsUser    `yaml:"users"`
+}
+
+type CortexOncallVictorOpsReference struct {
+	Name string `yaml:"name"`
+	Slug string `yaml:"slug"`
+}
+
+type CortexOncallVictorOpsUser struct {
+	OnCallUser struct {
+		Username string `yaml:"username"`
+	} `yaml:"onCalluser"`
+}
+
+// Used to represent the data we want to return in the table
+type CortexOncallRow struct {
+	EntityTag        string
+	Provider         string
+	Schedule         string
+	EscalationPolicy string
+	EscalationLevel  int
+	UserName         string
+	UserEmail        string
+	Start            string
+	End              string
+}
+
+func tableCortexOncall() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_oncall",
+		Description: "Cortex entity current on-call api.",
+		List: &plugin.ListConfig{
+			Hydrate: listOncallsHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "entity_tag", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "entity_tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity or team."},
+			{Name: "provider", Type: proto.ColumnType_STRING, Description: "On-call provider: pagerduty, opsgenie or victorops."},
+			{Name: "schedule", Type: proto.ColumnType_STRING, Description: "Schedule, or VictorOps team, of the on-call."},
+			{Name: "escalation_policy", Type: proto.ColumnType_STRING, Description: "Escalation policy of the on-call."},
+			{Name: "escalation_level", Type: proto.ColumnType_INT, Description: "Escalation level of the user, if the provider has levels."},
+			{Name: "user_name", Type: proto.ColumnType_STRING, Description: "Name or username of the user on call."},
+			{Name: "user_email", Type: proto.ColumnType_STRING, Description: "Email of the user on call, if known."},
+			{Name: "start_time", Type: proto.ColumnType_TIMESTAMP, Description: "Start of the on-call shift.", Transform: transform.FromField("Start").NullIfZero()},
+			{Name: "end_time", Type: proto.ColumnType_TIMESTAMP, Description: "End of the on-call shift.", Transform: transform.FromField("End").NullIfZero()},
+		},
+	}
+}
+
+func listOncallsHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+
+	tags, err := getEntityTags(ctx, d, client, "entity_tag")
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("listOncallsHydrator", "tags", len(tags))
+	return nil, listOncalls(ctx, client, &hydratorWriter, tags)
+}
+
+func listOncalls(ctx context.Context, client *req.Client, writer HydratorWriter, tags []string) error {
+	logger := plugin.Logger(ctx)
+
+	for _, tag := range tags {
+		logger.Debug("listOncalls", "tag", tag)
+		resp := client.
+			Get("/api/v1/catalog/{tag}/integrations/oncall/current").
+			SetPathParam("tag", tag).
+			Do(ctx)
+
+		// Entities without an on-call integration are not an error
+		if resp.GetStatusCode() == http.StatusNotFound {
+			continue
+		}
+
+		// Check for HTTP errors
+		if resp.IsErrorState() {
+			logger.Error("listOncalls", "tag", tag, "Status", resp.Status, "Body", resp.String())
+			return fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+		}
+
+		// Unmarshal the response and check for unmarshal errors
+		var response CortexOncallCurrentResponse
+		err := resp.Into(&response)
+		if err != nil {
+			logger.Error("listOncalls", "tag", tag, "Error", err)
+			return err
+		}
+
+		// An unsupported provider should not hide the on-call of other entities
+		rows, err := oncallRows(tag, response)
+		if err != nil {
+			logger.Warn("listOncalls", "tag", tag, "source", response.Source, "Error", err)
+			continue
+		}
+
+		for _, row := range rows {
+			// send the item to steampipe
+			writer.StreamListItem(ctx, row)
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if writer.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// oncallRows decodes the provider specific escalations into one row per user on call.
+func oncallRows(tag string, response CortexOncallCurrentResponse) ([]CortexOncallRow, error) {
+	provider := strings.ToLower(response.Source)
+	var rows []CortexOncallRow
+	if response.Escalations.IsZero() {
+		return rows, nil
+	}
+
+	switch provider {
+	case "pagerduty":
+		var oncalls []CortexOncallPagerDuty
+		if err := response.Escalations.Decode(&oncalls); err != nil {
+			return nil, err
+		}
+		for _, oncall := range oncalls {
+			rows = append(rows, CortexOncallRow{
+				EntityTag:        tag,
+				Provider:         provider,
+				Schedule:         oncall.Schedule.Summary,
+				EscalationPolicy: oncall.EscalationPolicy.Summary,
+				EscalationLevel:  oncall.EscalationLevel,
+				UserName:         firstNonEmpty(oncall.User.Name, oncall.User.Summary),
+				UserEmail:        oncall.User.Email,
+				Start:            oncall.Start,
+				End:              oncall.End,
+			})
+		}
+	case "opsgenie":
+		var oncalls []CortexOncallOpsGenie
+		if err := response.Escalations.Decode(&oncalls); err != nil {
+			return nil, err
+		}
+		for _, oncall := range oncalls {
+			for _, participant := range oncall.Participants {
+				row := CortexOncallRow{
+					EntityTag: tag,
+					Provider:  provider,
+					Schedule:  oncall.Parent.Name,
+					UserName:  participant.Name,
+				}
+				// OpsGenie users are identified by their email address
+				if strings.Contains(participant.Name, "@") {
+					row.UserEmail = participant.Name
+				}
+				rows = append(rows, row)
+			}
+		}
+	case "victorops":
+		var oncalls []CortexOncallVictorOpsTeam
+		if err := response.Escalations.Decode(&oncalls); err != nil {
+			return nil, err
+		}
+		for _, oncall := range oncalls {
+			for _, now := range oncall.OncallNow {
+				for _, user := range now.Users {
+					rows = append(rows, CortexOncallRow{
+						EntityTag:        tag,
+						Provider:         provider,
+						Schedule:         firstNonEmpty(oncall.Team.Name, oncall.Team.Slug),
+						EscalationPolicy: firstNonEmpty(now.EscalationPolicy.Name, now.EscalationPolicy.Slug),
+						UserName:         user.OnCallUser.Username,
+					})
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported on-call source %q", response.Source)
+	}
+	return rows, nil
+}
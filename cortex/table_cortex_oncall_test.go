@@ -0,0 +1,158 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func TestTableCortexOncall(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexOncall()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_oncall"))
+	g.Expect(table.Description).To(Equal("Cortex entity current on-call api."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(1))
+	g.Expect(table.List.KeyColumns[0].Name).To(Equal("entity_tag"))
+	g.Expect(table.List.KeyColumns[0].Require).To(Equal(plugin.Optional))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"entity_tag", proto.ColumnType_STRING},
+		{"provider", proto.ColumnType_STRING},
+		{"schedule", proto.ColumnType_STRING},
+		{"escalation_policy", proto.ColumnType_STRING},
+		{"escalation_level", proto.ColumnType_INT},
+		{"user_name", proto.ColumnType_STRING},
+		{"user_email", proto.ColumnType_STRING},
+		{"start_time", proto.ColumnType_TIMESTAMP},
+		{"end_time", proto.ColumnType_TIMESTAMP},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListOncallsProviders(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/pd-service/integrations/oncall/current"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, `{
+				"source": "PAGERDUTY",
+				"escalations": [{
+					"escalation_policy": {"summary": "Primary"},
+					"escalation_level": 1,
+					"schedule": {"summary": "Weekly"},
+					"user": {"summary": "Alice", "email": "alice@example.com"},
+					"start": "2025-01-01T00:00:00Z",
+					"end": "2025-01-08T00:00:00Z"
+				}]
+			}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/og-service/integrations/oncall/current"),
+			gh.RespondWith(http.StatusOK, `{
+				"source": "OPSGENIE",
+				"escalations": [{
+					"_parent": {"name": "og-schedule"},
+					"onCallParticipants": [{"name": "bob@example.com", "type": "user"}]
+				}]
+			}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/vo-service/integrations/oncall/current"),
+			gh.RespondWith(http.StatusOK, `{
+				"source": "VICTOROPS",
+				"escalations": [{
+					"team": {"name": "Ops", "slug": "team-ops"},
+					"oncallNow": [{
+						"escalationPolicy": {"name": "Ops Policy", "slug": "pol-ops"},
+						"users": [{"onCalluser": {"username": "carol"}}]
+					}]
+				}]
+			}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/no-oncall/integrations/oncall/current"),
+			gh.RespondWith(http.StatusNotFound, `{"details": "not found"}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/unknown/integrations/oncall/current"),
+			gh.RespondWith(http.StatusOK, `{"source": "XMATTERS", "escalations": [{}]}`, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexOncallRow](100)
+
+	err := listOncalls(ctx, client, writer, []string{"pd-service", "og-service", "vo-service", "no-oncall", "unknown"})
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(Equal([]CortexOncallRow{
+		{
+			EntityTag:        "pd-service",
+			Provider:         "pagerduty",
+			Schedule:         "Weekly",
+			EscalationPolicy: "Primary",
+			EscalationLevel:  1,
+			UserName:         "Alice",
+			UserEmail:        "alice@example.com",
+			Start:            "2025-01-01T00:00:00Z",
+			End:              "2025-01-08T00:00:00Z",
+		},
+		{
+			EntityTag: "og-service",
+			Provider:  "opsgenie",
+			Schedule:  "og-schedule",
+			UserName:  "bob@example.com",
+			UserEmail: "bob@example.com",
+		},
+		{
+			EntityTag:        "vo-service",
+			Provider:         "victorops",
+			Schedule:         "Ops",
+			EscalationPolicy: "Ops Policy",
+			UserName:         "carol",
+		},
+	}))
+}
+
+func TestListOncallsError(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/integrations/oncall/current"),
+			gh.RespondWith(http.StatusInternalServerError, "{\"details\": \"fake error\"}", nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexOncallRow](100)
+
+	err := listOncalls(ctx, client, writer, []string{"service1"})
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(Equal("error from cortex API 500 Internal Server Error: {\"details\": \"fake error\"}"))
+}
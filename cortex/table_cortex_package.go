@@ -0,0 +1,105 @@
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type CortexPackage struct {
+	ID          int    `yaml:"id"`
+	Name        string `yaml:"name"`
+	Version     string `yaml:"version"`
+	PackageType string `yaml:"packageType"`
+	DateCreated string `yaml:"dateCreated"`
+
+	// Any other fields, like version ranges, which depend on the package manager
+	Metadata map[string]interface{} `yaml:",inline"`
+
+	// Not in the API response, but used to enrich the data
+	EntityTag string `yaml:"-"`
+}
+
+func tableCortexPackage() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_package",
+		Description: "Cortex entity packages api.",
+		List: &plugin.ListConfig{
+			Hydrate: listPackagesHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "entity_tag", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "entity_tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity."},
+			{Name: "package_type", Type: proto.ColumnType_STRING, Description: "Package manager or language, e.g. GO, NPM, JAVA or PYTHON."},
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "Package name."},
+			{Name: "version", Type: proto.ColumnType_STRING, Description: "Package version."},
+			{Name: "date_created", Type: proto.ColumnType_TIMESTAMP, Description: "When the package was first seen."},
+			{Name: "metadata", Type: proto.ColumnType_JSON, Description: "Other package fields, like version ranges.", Transform: transform.FromField("Metadata")},
+		},
+	}
+}
+
+func listPackagesHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+
+	tags, err := getEntityTags(ctx, d, client, "entity_tag")
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("listPackagesHydrator", "tags", len(tags))
+	return nil, listPackages(ctx, client, &hydratorWriter, tags)
+}
+
+func listPackages(ctx context.Context, client *req.Client, writer HydratorWriter, tags []string) error {
+	logger := plugin.Logger(ctx)
+
+	for _, tag := range tags {
+		logger.Debug("listPackages", "tag", tag)
+		resp := client.
+			Get("/api/v1/catalog/{tag}/packages").
+			SetPathParam("tag", tag).
+			Do(ctx)
+
+		// Entities without packages are not an error
+		if resp.GetStatusCode() == http.StatusNotFound {
+			continue
+		}
+
+		// Check for HTTP errors
+		if resp.IsErrorState() {
+			logger.Error("listPackages", "tag", tag, "Status", resp.Status, "Body", resp.String())
+			return fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+		}
+
+		// Unmarshal the response and check for unmarshal errors
+		var response []CortexPackage
+		err := resp.Into(&response)
+		if err != nil {
+			logger.Error("listPackages", "tag", tag, "Error", err)
+			return err
+		}
+
+		for _, result := range response {
+			// enrich the data
+			result.EntityTag = tag
+			// send the item to steampipe
+			writer.StreamListItem(ctx, result)
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if writer.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+	}
+	return nil
+}
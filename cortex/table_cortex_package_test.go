@@ -0,0 +1,100 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func TestTableCortexPackage(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexPackage()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_package"))
+	g.Expect(table.Description).To(Equal("Cortex entity packages api."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(1))
+	g.Expect(table.List.KeyColumns[0].Name).To(Equal("entity_tag"))
+	g.Expect(table.List.KeyColumns[0].Require).To(Equal(plugin.Optional))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"entity_tag", proto.ColumnType_STRING},
+		{"package_type", proto.ColumnType_STRING},
+		{"name", proto.ColumnType_STRING},
+		{"version", proto.ColumnType_STRING},
+		{"date_created", proto.ColumnType_TIMESTAMP},
+		{"metadata", proto.ColumnType_JSON},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListPackages(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/packages"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, `[
+				{"id": 1, "name": "org.apache.logging.log4j:log4j-core", "version": "2.14.1", "packageType": "JAVA"},
+				{"id": 2, "name": "express", "version": "4.18.2", "packageType": "NODE", "versionRange": "^4.18.0"}
+			]`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service2/packages"),
+			gh.RespondWith(http.StatusNotFound, `{"details": "not found"}`, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexPackage](100)
+
+	err := listPackages(ctx, client, writer, []string{"service1", "service2"})
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(HaveLen(2))
+	g.Expect(writer.Items[0].EntityTag).To(Equal("service1"))
+	g.Expect(writer.Items[0].PackageType).To(Equal("JAVA"))
+	g.Expect(writer.Items[0].Version).To(Equal("2.14.1"))
+	g.Expect(writer.Items[0].Metadata).To(BeEmpty())
+	g.Expect(writer.Items[1].Metadata).To(Equal(map[string]interface{}{"versionRange": "^4.18.0"}))
+}
+
+func TestListPackagesError(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog/service1/packages"),
+			gh.RespondWith(http.StatusInternalServerError, "{\"details\": \"fake error\"}", nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexPackage](100)
+
+	err := listPackages(ctx, client, writer, []string{"service1"})
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(Equal("error from cortex API 500 Internal Server Error: {\"details\": \"fake error\"}"))
+}
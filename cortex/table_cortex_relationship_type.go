@@ -0,0 +1,165 @@
+package cortex
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type CortexRelationshipTypeResponse struct {
+	RelationshipTypes []CortexRelationshipType `yaml:"relationshipTypes"`
+	Page              int                      `yaml:"page"`
+	TotalPages        int                      `yaml:"totalPages"`
+	Total             int                      `yaml:"total"`
+}
+
+type CortexRelationshipType struct {
+	Tag                 string      `yaml:"tag"`
+	Name                string      `yaml:"name"`
+	Description         string      `yaml:"description"`
+	DefinitionLocation  string      `yaml:"definitionLocation"`
+	AllowCycles         bool        `yaml:"allowCycles"`
+	CreateCatalog       bool        `yaml:"createCatalog"`
+	IsSingleSource      bool        `yaml:"isSingleSource"`
+	IsSingleDestination bool        `yaml:"isSingleDestination"`
+	SourcesFilter       interface{} `yaml:"sourcesFilter"`
+	DestinationsFilter  interface{} `yaml:"destinationsFilter"`
+	Inheritances        interface{} `yaml:"inheritances"`
+}
+
+func tableCortexRelationshipType() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_relationship_type",
+		Description: "Cortex relationship types api.",
+		List: &plugin.ListConfig{
+			Hydrate: listRelationshipTypesHydrator,
+		},
+		Get: &plugin.GetConfig{
+			Hydrate:    getRelationshipTypeHydrator,
+			KeyColumns: plugin.SingleColumn("tag"),
+		},
+		Columns: []*plugin.Column{
+			{Name: "tag", Type: proto.ColumnType_STRING, Description: "Tag of the relationship type, e.g. runs-on."},
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "Pretty name of the relationship type."},
+			{Name: "description", Type: proto.ColumnType_STRING, Description: "Description."},
+			{Name: "definition_location", Type: proto.ColumnType_STRING, Description: "Which descriptor the relationship is defined in: SOURCE, DESTINATION or BOTH."},
+			{Name: "allow_cycles", Type: proto.ColumnType_BOOL, Description: "Whether the relationships may form cycles.", Transform: transform.FromField("AllowCycles")},
+			{Name: "create_catalog", Type: proto.ColumnType_BOOL, Description: "Whether Cortex creates a catalog for the relationship type.", Transform: transform.FromField("CreateCatalog")},
+			{Name: "is_single_source", Type: proto.ColumnType_BOOL, Description: "Whether a destination may only have one source.", Transform: transform.FromField("IsSingleSource")},
+			{Name: "is_single_destination", Type: proto.ColumnType_BOOL, Description: "Whether a source may only have one destination.", Transform: transform.FromField("IsSingleDestination")},
+			{Name: "sources_filter", Type: proto.ColumnType_JSON, Description: "Which entities may be sources.", Transform: transform.FromField("SourcesFilter")},
+			{Name: "destinations_filter", Type: proto.ColumnType_JSON, Description: "Which entities may be destinations.", Transform: transform.FromField("DestinationsFilter")},
+			{Name: "inheritances", Type: proto.ColumnType_JSON, Description: "Fields, such as owners, inherited along the relationship.", Transform: transform.FromField("Inheritances")},
+		},
+	}
+}
+
+func listRelationshipTypesHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+	return nil, listRelationshipTypes(ctx, client, &hydratorWriter)
+}
+
+func listRelationshipTypes(ctx context.Context, client *req.Client, writer HydratorWriter) error {
+	logger := plugin.Logger(ctx)
+
+	var response CortexRelationshipTypeResponse
+	var page int = 0
+	for {
+		logger.Debug("listRelationshipTypes", "page", page)
+		resp := client.
+			Get("/api/v1/relationship-types").
+			// Pagination
+			SetQueryParam("pageSize", "1000").
+			SetQueryParam("page", strconv.Itoa(page)).
+			Do(ctx)
+
+		// Check for HTTP errors
+		if resp.IsErrorState() {
+			logger.Error("listRelationshipTypes", "Status", resp.Status, "Body", resp.String())
+			return fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+		}
+
+		// Unmarshal the response and check for unmarshal errors
+		err := resp.Into(&response)
+		if err != nil {
+			logger.Error("listRelationshipTypes", "page", page, "Error", err)
+			return err
+		}
+
+		for _, result := range response.RelationshipTypes {
+			// send the item to steampipe
+			writer.StreamListItem(ctx, result)
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if writer.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+		page++
+		if page >= response.TotalPages {
+			break
+		}
+	}
+	return nil
+}
+
+// listRelationshipTypeTags returns the tags of every relationship type.
+func listRelationshipTypeTags(ctx context.Context, client *req.Client) ([]string, error) {
+	relationshipTypes := SliceWriter[CortexRelationshipType]{Limit: math.MaxInt64}
+	err := listRelationshipTypes(ctx, client, &relationshipTypes)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]string, 0, len(relationshipTypes.Items))
+	for _, relationshipType := range relationshipTypes.Items {
+		tags = append(tags, relationshipType.Tag)
+	}
+	return tags, nil
+}
+
+func getRelationshipTypeHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	tag := d.EqualsQuals["tag"].GetStringValue()
+	logger.Info("getRelationshipTypeHydrator", "tag", tag)
+	return getRelationshipType(ctx, client, tag)
+}
+
+// getRelationshipType returns a single relationship type, or nil if it does not exist.
+func getRelationshipType(ctx context.Context, client *req.Client, tag string) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+
+	resp := client.
+		Get("/api/v1/relationship-types/{tag}").
+		SetPathParam("tag", tag).
+		Do(ctx)
+
+	// A missing relationship type is not an error, there is just no row
+	if resp.GetStatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	// Check for HTTP errors
+	if resp.IsErrorState() {
+		logger.Error("getRelationshipType", "tag", tag, "Status", resp.Status, "Body", resp.String())
+		return nil, fmt.Errorf("error from cortex API %s: %s", resp.Status, resp.String())
+	}
+
+	// Unmarshal the response and check for unmarshal errors
+	var response CortexRelationshipType
+	err := resp.Into(&response)
+	if err != nil {
+		logger.Error("getRelationshipType", "tag", tag, "Error", err)
+		return nil, err
+	}
+	return response, nil
+}
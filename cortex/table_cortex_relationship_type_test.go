@@ -0,0 +1,104 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func TestTableCortexRelationshipType(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexRelationshipType()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_relationship_type"))
+	g.Expect(table.Description).To(Equal("Cortex relationship types api."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+
+	// Check get configuration.
+	g.Expect(table.Get).ToNot(BeNil())
+	g.Expect(table.Get.Hydrate).ToNot(BeNil())
+	g.Expect(table.Get.KeyColumns).To(HaveLen(1))
+	g.Expect(table.Get.KeyColumns[0].Name).To(Equal("tag"))
+	g.Expect(table.Get.KeyColumns[0].Require).To(Equal(plugin.Required))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"tag", proto.ColumnType_STRING},
+		{"name", proto.ColumnType_STRING},
+		{"description", proto.ColumnType_STRING},
+		{"definition_location", proto.ColumnType_STRING},
+		{"allow_cycles", proto.ColumnType_BOOL},
+		{"create_catalog", proto.ColumnType_BOOL},
+		{"is_single_source", proto.ColumnType_BOOL},
+		{"is_single_destination", proto.ColumnType_BOOL},
+		{"sources_filter", proto.ColumnType_JSON},
+		{"destinations_filter", proto.ColumnType_JSON},
+		{"inheritances", proto.ColumnType_JSON},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListRelationshipTypeTags(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/relationship-types", "page=0&pageSize=1000"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, `{"relationshipTypes": [{"tag": "runs-on", "allowCycles": false, "sourcesFilter": {"types": ["service"]}}], "page": 0, "totalPages": 2, "total": 2}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/relationship-types", "page=1&pageSize=1000"),
+			gh.RespondWith(http.StatusOK, `{"relationshipTypes": [{"tag": "consumes-topic"}], "page": 1, "totalPages": 2, "total": 2}`, nil),
+		),
+	)
+	defer server.Close()
+
+	tags, err := listRelationshipTypeTags(ctx, client)
+	g.Expect(err).To(BeNil())
+	g.Expect(tags).To(Equal([]string{"runs-on", "consumes-topic"}))
+}
+
+func TestGetRelationshipType(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/relationship-types/runs-on"),
+			gh.RespondWith(http.StatusOK, `{"tag": "runs-on", "name": "Runs on", "definitionLocation": "SOURCE", "isSingleDestination": true}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/relationship-types/missing"),
+			gh.RespondWith(http.StatusNotFound, `{"details": "not found"}`, nil),
+		),
+	)
+	defer server.Close()
+
+	item, err := getRelationshipType(ctx, client, "runs-on")
+	g.Expect(err).To(BeNil())
+	g.Expect(item).To(Equal(CortexRelationshipType{Tag: "runs-on", Name: "Runs on", DefinitionLocation: "SOURCE", IsSingleDestination: true}))
+
+	item, err = getRelationshipType(ctx, client, "missing")
+	g.Expect(err).To(BeNil())
+	g.Expect(item).To(BeNil())
+}
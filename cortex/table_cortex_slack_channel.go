@@ -0,0 +1,100 @@
+package cortex
+
+import (
+	"context"
+
+	"github.com/imroc/req/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+const (
+	SlackChannelOwnerEntity = "entity"
+	SlackChannelOwnerTeam   = "team"
+)
+
+// Used to represent a single slack channel of an entity or team in the table
+type CortexSlackChannelRow struct {
+	OwnerKind string
+	Tag       string
+	CortexSlackChannel
+}
+
+func tableCortexSlackChannel() *plugin.Table {
+	return &plugin.Table{
+		Name:        "cortex_slack_channel",
+		Description: "Slack channels of Cortex entities and teams.",
+		List: &plugin.ListConfig{
+			Hydrate: listSlackChannelsHydrator,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "owner_kind", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "owner_kind", Type: proto.ColumnType_STRING, Description: "Whether the channel belongs to an entity or a team."},
+			{Name: "tag", Type: proto.ColumnType_STRING, Description: "The x-cortex-tag of the entity or the teamTag of the team."},
+			{Name: "channel_name", Type: proto.ColumnType_STRING, Description: "Name of the slack channel.", Transform: transform.FromField("Name")},
+			{Name: "notifications_enabled", Type: proto.ColumnType_BOOL, Description: "Whether Cortex sends notifications to the channel.", Transform: transform.FromField("NotificationsEnabled")},
+			{Name: "description", Type: proto.ColumnType_STRING, Description: "Description."},
+		},
+	}
+}
+
+func listSlackChannelsHydrator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+	client := CortexHTTPClient(ctx, config)
+	hydratorWriter := QueryDataWriter{d}
+
+	ownerKinds := []string{SlackChannelOwnerEntity, SlackChannelOwnerTeam}
+	if d.EqualsQuals["owner_kind"] != nil {
+		ownerKinds = []string{d.EqualsQuals["owner_kind"].GetStringValue()}
+	}
+
+	logger.Info("listSlackChannelsHydrator", "ownerKinds", ownerKinds)
+	return nil, listSlackChannels(ctx, client, &hydratorWriter, ownerKinds)
+}
+
+func listSlackChannels(ctx context.Context, client *req.Client, writer HydratorWriter, ownerKinds []string) error {
+	for _, ownerKind := range ownerKinds {
+		var err error
+		switch ownerKind {
+		case SlackChannelOwnerEntity:
+			entityWriter := ExpandWriter[CortexEntityElement]{
+				Writer: writer,
+				Expand: func(entity CortexEntityElement) []interface{} {
+					return slackChannelRows(SlackChannelOwnerEntity, entity.Tag, entity.Slack)
+				},
+			}
+			err = listEntities(ctx, client, &entityWriter, "false", "", "")
+		case SlackChannelOwnerTeam:
+			teamWriter := ExpandWriter[CortexTeamElement]{
+				Writer: writer,
+				Expand: func(team CortexTeamElement) []interface{} {
+					return slackChannelRows(SlackChannelOwnerTeam, team.Tag, team.Slack)
+				},
+			}
+			err = listTeams(ctx, client, &teamWriter, nil)
+		}
+		if err != nil {
+			return err
+		}
+		if writer.RowsRemaining(ctx) == 0 {
+			return nil
+		}
+	}
+	return nil
+}
+
+func slackChannelRows(ownerKind string, tag string, channels []CortexSlackChannel) []interface{} {
+	var rows []interface{}
+	for _, channel := range channels {
+		rows = append(rows, CortexSlackChannelRow{
+			OwnerKind:          ownerKind,
+			Tag:                tag,
+			CortexSlackChannel: channel,
+		})
+	}
+	return rows
+}
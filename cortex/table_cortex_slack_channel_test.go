@@ -0,0 +1,103 @@
+package cortex
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func TestTableCortexSlackChannel(t *testing.T) {
+	g := NewWithT(t)
+	table := tableCortexSlackChannel()
+
+	// Check basic table properties.
+	g.Expect(table).ToNot(BeNil())
+	g.Expect(table.Name).To(Equal("cortex_slack_channel"))
+	g.Expect(table.Description).To(Equal("Slack channels of Cortex entities and teams."))
+
+	// Check list configuration.
+	g.Expect(table.List).ToNot(BeNil())
+	g.Expect(table.List.Hydrate).ToNot(BeNil())
+	g.Expect(table.List.KeyColumns).To(HaveLen(1))
+	g.Expect(table.List.KeyColumns[0].Name).To(Equal("owner_kind"))
+	g.Expect(table.List.KeyColumns[0].Require).To(Equal(plugin.Optional))
+
+	// Define expected columns.
+	expectedColumns := []struct {
+		Name string
+		Type proto.ColumnType
+	}{
+		{"owner_kind", proto.ColumnType_STRING},
+		{"tag", proto.ColumnType_STRING},
+		{"channel_name", proto.ColumnType_STRING},
+		{"notifications_enabled", proto.ColumnType_BOOL},
+		{"description", proto.ColumnType_STRING},
+	}
+
+	// Check that the table has the expected columns.
+	g.Expect(table.Columns).To(HaveLen(len(expectedColumns)))
+	for i, exp := range expectedColumns {
+		g.Expect(table.Columns[i].Name).To(Equal(exp.Name))
+		g.Expect(table.Columns[i].Type).To(Equal(exp.Type))
+	}
+}
+
+func TestListSlackChannels(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/catalog"),
+			gh.VerifyHeaderKV("Authorization", "Bearer fake_api_key"),
+			gh.RespondWith(http.StatusOK, `{
+				"entities": [
+					{"tag": "service1", "slackChannels": [{"name": "service1-alerts", "notificationsEnabled": true, "description": "Alerts"}, {"name": "service1-dev", "notificationsEnabled": false}]},
+					{"tag": "service2"}
+				],
+				"page": 0,
+				"totalPages": 1,
+				"total": 2
+			}`, nil),
+		),
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/teams"),
+			gh.RespondWith(http.StatusOK, `{"teams": [{"teamTag": "team1", "slackChannels": [{"name": "team1", "notificationsEnabled": true}]}]}`, nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexSlackChannelRow](100)
+
+	err := listSlackChannels(ctx, client, writer, []string{SlackChannelOwnerEntity, SlackChannelOwnerTeam})
+	g.Expect(err).To(BeNil())
+
+	g.Expect(writer.Items).To(Equal([]CortexSlackChannelRow{
+		{OwnerKind: "entity", Tag: "service1", CortexSlackChannel: CortexSlackChannel{Name: "service1-alerts", NotificationsEnabled: true, Description: "Alerts"}},
+		{OwnerKind: "entity", Tag: "service1", CortexSlackChannel: CortexSlackChannel{Name: "service1-dev"}},
+		{OwnerKind: "team", Tag: "team1", CortexSlackChannel: CortexSlackChannel{Name: "team1", NotificationsEnabled: true}},
+	}))
+}
+
+func TestListSlackChannelsError(t *testing.T) {
+	g := NewWithT(t)
+	gh := ghttp.NewGHTTPWithGomega(g)
+
+	ctx, server, client := setupTestServerAndClient(t,
+		ghttp.CombineHandlers(
+			gh.VerifyRequest("GET", "/api/v1/teams"),
+			gh.RespondWith(http.StatusInternalServerError, "{\"details\": \"fake error on teams\"}", nil),
+		),
+	)
+	defer server.Close()
+
+	writer := NewSliceWriter[CortexSlackChannelRow](100)
+
+	err := listSlackChannels(ctx, client, writer, []string{SlackChannelOwnerTeam})
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(Equal("error from cortex API 500 Internal Server Error: {\"details\": \"fake error on teams\"}"))
+}
@@ -62,7 +62,7 @@ func tableCortexTeam() *plugin.Table {
 			{Name: "metadata", Type: proto.ColumnType_JSON, Description: "Raw custom metadata"},
 			{Name: "links", Type: proto.ColumnType_JSON, Description: "List of links", Transform: FromStructSlice[CortexLink]("Links", "Url")},
 			{Name: "archived", Type: proto.ColumnType_BOOL, Description: "Is archived."},
-			{Name: "slack_channels", Type: proto.ColumnType_JSON, Description: "List of string slack channels"},
+			{Name: "slack_channels", Type: proto.ColumnType_JSON, Description: "List of slack channels, each with name, notificationsEnabled and description."},
 			{Name: "members", Type: proto.ColumnType_JSON, Description: "List of members", Transform: transform.FromField("IDPGroup.Members")},
 		},
 	}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/imroc/req/v3"
 	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 	"gopkg.in/yaml.v3"
 )
@@ -43,6 +45,106 @@ func FromStructSlice[T any](field string, child string) *transform.ColumnTransfo
 	}}
 }
 
+// Get git field from the data, normalise it across providers and return the
+// nested field "child" of the resulting CortexEntityGit.
+func FromGit(field string, child string) *transform.ColumnTransforms {
+	return &transform.ColumnTransforms{Transforms: []*transform.TransformCall{
+		{Transform: transform.FieldValue, Param: field},
+		{Transform: func(ctx context.Context, td *transform.TransformData) (interface{}, error) {
+			git, ok := td.Value.(interface{ Normalize() CortexEntityGit })
+			if !ok {
+				return nil, nil
+			}
+			value, _ := helpers.GetNestedFieldValueFromInterface(git.Normalize(), child)
+			if value == "" {
+				return nil, nil
+			}
+			return value, nil
+		}},
+	}}
+}
+
+// Get field from the data and convert it to the same shape as the YAML it
+// was decoded from, so JSON columns use descriptor keys rather than Go field
+// names. Empty values are returned as nil.
+func FromYAMLField(field string) *transform.ColumnTransforms {
+	return &transform.ColumnTransforms{Transforms: []*transform.TransformCall{
+		{Transform: transform.FieldValue, Param: field},
+		{Transform: func(ctx context.Context, td *transform.TransformData) (interface{}, error) {
+			out, err := yaml.Marshal(td.Value)
+			if err != nil {
+				return nil, err
+			}
+			var value interface{}
+			err = yaml.Unmarshal(out, &value)
+			if err != nil {
+				return nil, err
+			}
+			switch v := value.(type) {
+			case map[string]interface{}:
+				if len(v) == 0 {
+					return nil, nil
+				}
+			case []interface{}:
+				if len(v) == 0 {
+					return nil, nil
+				}
+			}
+			return value, nil
+		}},
+	}}
+}
+
+// gitRepositoryURL builds a browsable URL for a repository from its name,
+// assuming it is hosted on github.com, gitlab.com or bitbucket.org. It is only
+// a fallback for when the API returns no URL: the repository name alone
+// cannot tell a self hosted instance apart, so its URL will be wrong. Azure
+// DevOps, which needs the organization name, returns an empty string.
+func gitRepositoryURL(provider string, repository string) string {
+	if repository == "" {
+		return ""
+	}
+	switch provider {
+	case "github":
+		return "https://github.com/" + repository
+	case "gitlab":
+		return "https://gitlab.com/" + repository
+	case "bitbucket":
+		return "https://bitbucket.org/" + repository
+	}
+	return ""
+}
+
+// qualStrings returns the non empty strings of an equals qual, which is a list
+// for `in (...)`. It is nil if there is no qual or it has no strings.
+func qualStrings(value *proto.QualValue) []string {
+	if value == nil {
+		return nil
+	}
+	if s := value.GetStringValue(); s != "" {
+		return []string{s}
+	}
+	var values []string
+	if listValue := value.GetListValue(); listValue != nil {
+		for _, v := range listValue.Values {
+			if s := v.GetStringValue(); s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
+// firstNonEmpty returns the first of the values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
 func TagArrayToMap(ctx context.Context, d *transform.TransformData) (interface{}, error) {
 	result := map[string]interface{}{}
 	for _, value := range d.Value.([]CortexEntityElementMetadata) {
@@ -51,6 +153,69 @@ func TagArrayToMap(ctx context.Context, d *transform.TransformData) (interface{}
 	return result, nil
 }
 
+// TimeRange is an inclusive, optionally open ended, range of time built from
+// the quals on a timestamp column.
+type TimeRange struct {
+	Start *time.Time
+	End   *time.Time
+}
+
+// timeRangeFromQuals builds a TimeRange from the =, >, >=, < and <= quals of a column.
+func timeRangeFromQuals(timeQuals []*quals.Qual) TimeRange {
+	var timeRange TimeRange
+	for _, q := range timeQuals {
+		if q.Value.GetTimestampValue() == nil {
+			continue
+		}
+		value := q.Value.GetTimestampValue().AsTime()
+		switch q.Operator {
+		case quals.QualOperatorEqual:
+			timeRange.Start = &value
+			timeRange.End = &value
+		case quals.QualOperatorGreater, quals.QualOperatorGreaterOrEqual:
+			timeRange.Start = &value
+		case quals.QualOperatorLess, quals.QualOperatorLessOrEqual:
+			timeRange.End = &value
+		}
+	}
+	return timeRange
+}
+
+// Contains checks if the RFC3339 timestamp is within the range. Timestamps
+// which cannot be parsed are always included and left for steampipe to filter.
+func (r TimeRange) Contains(timestamp string) bool {
+	value, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return true
+	}
+	if r.Start != nil && value.Before(*r.Start) {
+		return false
+	}
+	if r.End != nil && value.After(*r.End) {
+		return false
+	}
+	return true
+}
+
+// QueryParams returns the bounds of the range formatted as RFC3339 query
+// parameters, omitting any open end. RFC3339 has whole seconds, so a fractional
+// end is rounded up to include the whole range, and steampipe does the exact
+// filtering.
+func (r TimeRange) QueryParams(startParam string, endParam string) map[string]string {
+	params := map[string]string{}
+	if r.Start != nil {
+		params[startParam] = r.Start.UTC().Format(time.RFC3339)
+	}
+	if r.End != nil {
+		end := r.End.UTC()
+		if truncated := end.Truncate(time.Second); !truncated.Equal(end) {
+			end = truncated.Add(time.Second)
+		}
+		params[endParam] = end.Format(time.RFC3339)
+	}
+	return params
+}
+
 // Writer is a generic interface to stream items of any type.
 type HydratorWriter interface {
 	StreamListItem(ctx context.Context, items ...interface{})
@@ -70,7 +235,28 @@ func (h *QueryDataWriter) RowsRemaining(ctx context.Context) int64 {
 	return h.QueryData.RowsRemaining(ctx)
 }
 
-// Testing implementation that writes to a slice up to a fixed limit.
+// ExpandWriter wraps another HydratorWriter and expands each streamed item of
+// type T into zero or more rows. This lets tables that produce one row per
+// nested element reuse the pagination of an existing list function.
+type ExpandWriter[T any] struct {
+	Writer HydratorWriter
+	Expand func(item T) []interface{}
+}
+
+func (e *ExpandWriter[T]) StreamListItem(ctx context.Context, items ...interface{}) {
+	for _, item := range items {
+		if typedItem, ok := item.(T); ok {
+			e.Writer.StreamListItem(ctx, e.Expand(typedItem)...)
+		}
+	}
+}
+
+func (e *ExpandWriter[T]) RowsRemaining(ctx context.Context) int64 {
+	return e.Writer.RowsRemaining(ctx)
+}
+
+// Implementation that writes to a slice up to a fixed limit. Used in tests and
+// to collect items from a list function before fanning out to other APIs.
 type SliceWriter[T any] struct {
 	Limit int64
 	Items []T